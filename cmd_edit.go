@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/db"
+	"github.com/happytaoer/cli_kanban/internal/due"
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/taskfile"
+	"github.com/spf13/cobra"
+)
+
+// newEditCmd creates the "edit" command
+func newEditCmd() *cobra.Command {
+	editCmd := &cobra.Command{
+		Use:   "edit <task-id>",
+		Short: "Edit a task; without flags it opens in $VISUAL or $EDITOR",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runEdit,
+	}
+	editCmd.Flags().String("title", "", "New title")
+	editCmd.Flags().String("status", "", "New status (todo, in_progress, done)")
+	editCmd.Flags().String("tags", "", "Comma-separated tags, replacing the current ones (\"\" clears them)")
+	editCmd.Flags().String("due", "", "Due date, e.g. fri, +3d, \"next monday 9am\" or 2026-11-01 (none clears it)")
+	editCmd.Flags().String("description", "", "New description (Markdown)")
+	return editCmd
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	id, err := parseTaskID(args[0])
+	if err != nil {
+		return err
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	task, err := database.GetTask(id)
+	if err != nil {
+		return fmt.Errorf("task %d: %w", id, err)
+	}
+
+	flags := cmd.Flags()
+	if !flags.Changed("title") && !flags.Changed("status") && !flags.Changed("tags") &&
+		!flags.Changed("due") && !flags.Changed("description") {
+		return editInEditor(database, task)
+	}
+
+	edit := task.Edit()
+	if flags.Changed("title") {
+		title, _ := flags.GetString("title")
+		if edit.Title = strings.TrimSpace(title); edit.Title == "" {
+			return fmt.Errorf("--title must not be empty")
+		}
+	}
+	if flags.Changed("status") {
+		status, _ := flags.GetString("status")
+		if edit.Status, err = model.ParseStatus(status); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("tags") {
+		tags, _ := flags.GetString("tags")
+		edit.Tags = strings.Split(tags, ",")
+	}
+	if flags.Changed("due") {
+		value, _ := flags.GetString("due")
+		if edit.Due, err = due.Parse(value, time.Now()); err != nil {
+			return fmt.Errorf("invalid --due: %w", err)
+		}
+	}
+	if flags.Changed("description") {
+		edit.Description, _ = flags.GetString("description")
+	}
+
+	if err := database.ApplyTaskEdit(id, task.Edit(), edit); err != nil {
+		return err
+	}
+	fmt.Printf("Updated task #%d\n", id)
+	return nil
+}
+
+// editInEditor opens the task in the user's editor and saves the result.
+// When the file does not parse, the error is added to it and the editor
+// reopens; declining leaves the file in place so the edit is not lost.
+func editInEditor(database *db.DB, task *model.Task) error {
+	original := taskfile.Normalize(task.Edit())
+	path, err := taskfile.WriteTemp(taskfile.Format(original))
+	if err != nil {
+		return err
+	}
+
+	for {
+		editor := taskfile.EditorCommand(path)
+		editor.Stdin, editor.Stdout, editor.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := editor.Run(); err != nil {
+			os.Remove(path)
+			return fmt.Errorf("failed to run editor: %w", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read edited task: %w", err)
+		}
+
+		edit, parseErr := taskfile.Parse(string(data))
+		if parseErr == nil {
+			os.Remove(path)
+			if edit.Equal(original) {
+				fmt.Println("No changes")
+				return nil
+			}
+			if err := database.ApplyTaskEdit(task.ID, original, edit); err != nil {
+				return err
+			}
+			fmt.Printf("Updated task #%d\n", task.ID)
+			return nil
+		}
+
+		if err := os.WriteFile(path, []byte(taskfile.WithProblem(string(data), parseErr)), 0o600); err != nil {
+			return fmt.Errorf("failed to write temp file: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", parseErr)
+		if !askYes("Edit again? [Y/n] ") {
+			return fmt.Errorf("task #%d not saved; your edit is in %s", task.ID, path)
+		}
+	}
+}
+
+// stdin is shared by prompts so buffered input is not lost between them
+var stdin = bufio.NewReader(os.Stdin)
+
+// askYes prompts on stdout and reports whether the answer is not "n".
+// A closed stdin counts as no.
+func askYes(prompt string) bool {
+	fmt.Print(prompt)
+	answer, err := stdin.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Println()
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer != "n" && answer != "no"
+}
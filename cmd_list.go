@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/db"
+	"github.com/happytaoer/cli_kanban/internal/due"
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/query"
+	"github.com/spf13/cobra"
+)
+
+// newListCmd creates the "list" command
+func newListCmd() *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List tasks",
+		Args:  cobra.NoArgs,
+		RunE:  runList,
+	}
+	listCmd.Flags().String("sprint", "", "Only tasks in this sprint (ID, name, \"current\" or \"backlog\")")
+	listCmd.Flags().String("status", "", "Only tasks with this status (todo, in_progress, done)")
+	listCmd.Flags().String("filter", "", "Only tasks matching a search query, e.g. 'tag:bug due:overdue'")
+	listCmd.Flags().String("view", "", "Apply a saved view's query, sort and columns")
+	listCmd.Flags().Bool("archived", false, "Include archived tasks")
+	return listCmd
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	sprintRef, _ := cmd.Flags().GetString("sprint")
+	status, _ := cmd.Flags().GetString("status")
+	filterStr, _ := cmd.Flags().GetString("filter")
+	viewName, _ := cmd.Flags().GetString("view")
+	archived, _ := cmd.Flags().GetBool("archived")
+
+	filter, err := query.Parse(filterStr)
+	if err != nil {
+		return fmt.Errorf("invalid --filter: %w", err)
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	var view *model.SavedView
+	viewFilter := &query.Query{}
+	if viewName != "" {
+		if view, err = database.GetView(viewName); err != nil {
+			return err
+		}
+		if viewFilter, err = query.Parse(view.Query); err != nil {
+			return fmt.Errorf("invalid query in view %q: %w", view.Name, err)
+		}
+	}
+
+	tasks, err := database.GetAllTasks()
+	if err != nil {
+		return err
+	}
+
+	if !archived {
+		var active []model.Task
+		for _, t := range tasks {
+			if t.ArchivedAt == nil {
+				active = append(active, t)
+			}
+		}
+		tasks = active
+	}
+
+	if sprintRef != "" {
+		var sprintID int64
+		if !isBacklogRef(sprintRef) {
+			sprint, err := database.ResolveSprint(sprintRef)
+			if err != nil {
+				return err
+			}
+			sprintID = sprint.ID
+		}
+		tasks = filterTasksBySprint(tasks, sprintID)
+	}
+
+	if status != "" {
+		var filtered []model.Task
+		for _, t := range tasks {
+			if string(t.Status) == status {
+				filtered = append(filtered, t)
+			}
+		}
+		tasks = filtered
+	}
+
+	for _, q := range []*query.Query{viewFilter, filter} {
+		if tasks, err = filterTasks(database, tasks, q); err != nil {
+			return err
+		}
+	}
+
+	if view != nil {
+		var shown []model.Task
+		for _, t := range tasks {
+			if view.ShowsColumn(t.Status) {
+				shown = append(shown, t)
+			}
+		}
+		tasks = shown
+		model.SortTasks(tasks, view.Sort)
+	}
+
+	return printTasks(tasks)
+}
+
+// filterTasks returns the tasks matching q, answering its text terms from
+// the full-text index
+func filterTasks(database *db.DB, tasks []model.Task, q *query.Query) ([]model.Task, error) {
+	if q.Empty() {
+		return tasks, nil
+	}
+	hits, err := q.TextHits(func(text string) (map[int64]bool, error) {
+		return database.SearchTaskIDs(text, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return q.Filter(tasks, query.Env{Now: time.Now(), TextHits: hits}), nil
+}
+
+// printTasks prints tasks as a table
+func printTasks(tasks []model.Task) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tTITLE\tTAGS\tDUE\tEST")
+	for _, t := range tasks {
+		dueText := "-"
+		if t.Due != nil {
+			dueText = due.Format(*t.Due)
+		}
+		est := "-"
+		if t.Estimate != nil {
+			est = fmt.Sprintf("%g", *t.Estimate)
+		}
+		tags := strings.Join(t.Tags, ",")
+		if tags == "" {
+			tags = "-"
+		}
+		status := string(t.Status)
+		if t.ArchivedAt != nil {
+			status += " (archived)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", t.ID, status, t.Title, tags, dueText, est)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/report"
+	"github.com/spf13/cobra"
+)
+
+// newReportCmd creates the "report" command group for board analytics
+func newReportCmd() *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Charts and analytics computed from task status history",
+	}
+
+	burndownCmd := &cobra.Command{
+		Use:   "burndown",
+		Short: "Sprint burndown chart",
+		Args:  cobra.NoArgs,
+		RunE:  runReportBurndown,
+	}
+	burndownCmd.Flags().String("sprint", "current", "Sprint ID, name or \"current\"")
+	burndownCmd.Flags().Bool("points", false, "Burn down estimates instead of task counts")
+	addChartFlags(burndownCmd)
+	reportCmd.AddCommand(burndownCmd)
+
+	cfdCmd := &cobra.Command{
+		Use:   "cfd",
+		Short: "Cumulative flow diagram",
+		Args:  cobra.NoArgs,
+		RunE:  runReportCFD,
+	}
+	cfdCmd.Flags().String("since", "", "First day (YYYY-MM-DD, default 30 days ago)")
+	cfdCmd.Flags().String("until", "", "Last day (YYYY-MM-DD, default today)")
+	addChartFlags(cfdCmd)
+	reportCmd.AddCommand(cfdCmd)
+
+	flowCmd := &cobra.Command{
+		Use:   "flow",
+		Short: "Lead time, cycle time, throughput and aging work in progress",
+		Args:  cobra.NoArgs,
+		RunE:  runReportFlow,
+	}
+	flowCmd.Flags().String("since", "", "Only tasks completed on or after this date (YYYY-MM-DD)")
+	flowCmd.Flags().String("tag", "", "Only tasks with this tag")
+	flowCmd.Flags().Bool("json", false, "Print the report as JSON")
+	reportCmd.AddCommand(flowCmd)
+
+	return reportCmd
+}
+
+// addChartFlags adds the chart size flags
+func addChartFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("width", 60, "Chart width in characters")
+	cmd.Flags().Int("height", 12, "Chart height in lines")
+}
+
+func runReportBurndown(cmd *cobra.Command, args []string) error {
+	sprintRef, _ := cmd.Flags().GetString("sprint")
+	points, _ := cmd.Flags().GetBool("points")
+	width, _ := cmd.Flags().GetInt("width")
+	height, _ := cmd.Flags().GetInt("height")
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	sprint, err := database.ResolveSprint(sprintRef)
+	if err != nil {
+		return err
+	}
+	tasks, err := database.GetAllTasks()
+	if err != nil {
+		return err
+	}
+	carryovers, err := database.GetCarryovers(sprint.ID)
+	if err != nil {
+		return err
+	}
+	transitions, err := database.GetStatusTransitions()
+	if err != nil {
+		return err
+	}
+
+	weight, unit := report.CountWeight, "tasks"
+	if points {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		weight, unit = report.EstimateWeight, string(cfg.Estimate.Unit)
+	}
+
+	scope := report.SprintScope(*sprint, tasks, carryovers)
+	series := report.Burndown(*sprint, scope, report.Timelines(transitions), weight, time.Now())
+
+	fmt.Printf("Burndown: %s (%s → %s), remaining %s\n\n", sprint.Name,
+		sprint.Start.Format("2006-01-02"), sprint.End.Format("2006-01-02"), unit)
+	fmt.Print(report.BurndownChart(series, width, height))
+	return nil
+}
+
+func runReportCFD(cmd *cobra.Command, args []string) error {
+	sinceStr, _ := cmd.Flags().GetString("since")
+	untilStr, _ := cmd.Flags().GetString("until")
+	width, _ := cmd.Flags().GetInt("width")
+	height, _ := cmd.Flags().GetInt("height")
+
+	now := time.Now()
+	until := now
+	if untilStr != "" {
+		t, err := parseDateFlag(untilStr)
+		if err != nil {
+			return fmt.Errorf("invalid --until: %w", err)
+		}
+		until = t
+	}
+	since := until.AddDate(0, 0, -30)
+	if sinceStr != "" {
+		t, err := parseDateFlag(sinceStr)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		since = t
+	}
+	if until.Before(since) {
+		return fmt.Errorf("--until must not be before --since")
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	transitions, err := database.GetStatusTransitions()
+	if err != nil {
+		return err
+	}
+
+	series := report.CumulativeFlow(report.Timelines(transitions), since, until, now)
+	fmt.Printf("Cumulative flow: %s → %s\n\n", since.Format("2006-01-02"), until.Format("2006-01-02"))
+	fmt.Print(report.CFDChart(series, report.DefaultBands(), width, height))
+	return nil
+}
+
+func runReportFlow(cmd *cobra.Command, args []string) error {
+	sinceStr, _ := cmd.Flags().GetString("since")
+	tag, _ := cmd.Flags().GetString("tag")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	var since time.Time
+	if sinceStr != "" {
+		t, err := parseDateFlag(sinceStr)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		since = t
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	tasks, err := database.GetAllTasks()
+	if err != nil {
+		return err
+	}
+	transitions, err := database.GetStatusTransitions()
+	if err != nil {
+		return err
+	}
+
+	if tag != "" {
+		tasks = filterTasksByTag(tasks, tag)
+	}
+
+	flow := report.Flow(tasks, report.Timelines(transitions), since, time.Now())
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(newFlowJSON(flow))
+	}
+	return printFlow(flow)
+}
+
+// filterTasksByTag returns tasks carrying the given tag
+func filterTasksByTag(tasks []model.Task, tag string) []model.Task {
+	var filtered []model.Task
+	for _, t := range tasks {
+		for _, tt := range t.Tags {
+			if strings.EqualFold(tt, tag) {
+				filtered = append(filtered, t)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// printFlow prints a flow report as text tables
+func printFlow(flow report.FlowReport) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Completed tasks: %d\n\n", len(flow.Completed))
+	fmt.Fprintln(w, "\tP50\tP85\tP95")
+	fmt.Fprintf(w, "Lead time\t%s\t%s\t%s\n", formatDays(flow.LeadTime.P50), formatDays(flow.LeadTime.P85), formatDays(flow.LeadTime.P95))
+	fmt.Fprintf(w, "Cycle time\t%s\t%s\t%s\n", formatDays(flow.CycleTime.P50), formatDays(flow.CycleTime.P85), formatDays(flow.CycleTime.P95))
+
+	fmt.Fprintln(w, "\nThroughput per week")
+	for _, wk := range flow.Throughput {
+		fmt.Fprintf(w, "  %s\t%s %d\n", wk.WeekStart.Format("2006-01-02"), strings.Repeat("█", wk.Count), wk.Count)
+	}
+
+	fmt.Fprintln(w, "\nAging work in progress")
+	if len(flow.Aging) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, a := range flow.Aging {
+		fmt.Fprintf(w, "  #%d\t%s\t%s\n", a.Task.ID, formatDays(a.Age), a.Task.Title)
+	}
+
+	fmt.Fprintln(w, "\nCompleted")
+	fmt.Fprintln(w, "  ID\tDONE\tLEAD\tCYCLE\tTITLE")
+	for _, f := range flow.Completed {
+		cycle := "-"
+		if f.StartedAt != nil {
+			cycle = formatDays(f.CycleTime)
+		}
+		fmt.Fprintf(w, "  #%d\t%s\t%s\t%s\t%s\n", f.Task.ID, f.DoneAt.Local().Format("2006-01-02"),
+			formatDays(f.LeadTime), cycle, f.Task.Title)
+	}
+
+	return w.Flush()
+}
+
+// formatDays formats a duration in days, or hours when under a day
+func formatDays(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%.1fh", d.Hours())
+	}
+	return fmt.Sprintf("%.1fd", d.Hours()/24)
+}
+
+// flowJSON is the JSON form of a flow report; durations are in hours
+type flowJSON struct {
+	LeadTime   percentilesJSON  `json:"lead_time_hours"`
+	CycleTime  percentilesJSON  `json:"cycle_time_hours"`
+	Throughput []throughputJSON `json:"throughput_per_week"`
+	Aging      []agingJSON      `json:"aging_wip"`
+	Completed  []taskFlowJSON   `json:"completed"`
+}
+
+type percentilesJSON struct {
+	P50 float64 `json:"p50"`
+	P85 float64 `json:"p85"`
+	P95 float64 `json:"p95"`
+}
+
+type throughputJSON struct {
+	WeekStart string `json:"week_start"`
+	Count     int    `json:"count"`
+}
+
+type agingJSON struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	StartedAt time.Time `json:"started_at"`
+	AgeHours  float64   `json:"age_hours"`
+}
+
+type taskFlowJSON struct {
+	ID         int64      `json:"id"`
+	Title      string     `json:"title"`
+	Tags       []string   `json:"tags"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	DoneAt     time.Time  `json:"done_at"`
+	LeadHours  float64    `json:"lead_time_hours"`
+	CycleHours *float64   `json:"cycle_time_hours,omitempty"`
+}
+
+// newFlowJSON converts a flow report to its JSON form
+func newFlowJSON(flow report.FlowReport) flowJSON {
+	toPercentiles := func(p report.Percentiles) percentilesJSON {
+		return percentilesJSON{P50: p.P50.Hours(), P85: p.P85.Hours(), P95: p.P95.Hours()}
+	}
+
+	out := flowJSON{
+		LeadTime:   toPercentiles(flow.LeadTime),
+		CycleTime:  toPercentiles(flow.CycleTime),
+		Throughput: []throughputJSON{},
+		Aging:      []agingJSON{},
+		Completed:  []taskFlowJSON{},
+	}
+	for _, wk := range flow.Throughput {
+		out.Throughput = append(out.Throughput, throughputJSON{WeekStart: wk.WeekStart.Format("2006-01-02"), Count: wk.Count})
+	}
+	for _, a := range flow.Aging {
+		out.Aging = append(out.Aging, agingJSON{ID: a.Task.ID, Title: a.Task.Title, StartedAt: a.StartedAt, AgeHours: a.Age.Hours()})
+	}
+	for _, f := range flow.Completed {
+		tf := taskFlowJSON{
+			ID:        f.Task.ID,
+			Title:     f.Task.Title,
+			Tags:      f.Task.Tags,
+			CreatedAt: f.CreatedAt,
+			StartedAt: f.StartedAt,
+			DoneAt:    f.DoneAt,
+			LeadHours: f.LeadTime.Hours(),
+		}
+		if f.StartedAt != nil {
+			cycle := f.CycleTime.Hours()
+			tf.CycleHours = &cycle
+		}
+		out.Completed = append(out.Completed, tf)
+	}
+	return out
+}
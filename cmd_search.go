@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// newSearchCmd creates the "search" command
+func newSearchCmd() *cobra.Command {
+	searchCmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Full-text search over task titles, descriptions and tags",
+		Long: `Full-text search over task titles, descriptions and tags, best matches first.
+
+Every word must match. Quote words to match them as a phrase and end a word
+with * to match prefixes:
+
+  cli_kanban search '"login page" auth*'`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runSearch,
+	}
+	searchCmd.Flags().Int("limit", 50, "Maximum number of results (0 for all)")
+	return searchCmd
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if !database.HasFullTextSearch() {
+		fmt.Fprintln(os.Stderr, "Note: this build has no SQLite FTS5, so results are not ranked; build with `make` or -tags sqlite_fts5")
+	}
+
+	tasks, err := database.SearchTasks(strings.Join(args, " "), limit)
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		fmt.Println("No matching tasks")
+		return nil
+	}
+	return printTasks(tasks)
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/db"
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// defaultSprintDays is the length of a new sprint when --end is not given
+const defaultSprintDays = 14
+
+// newSprintCmd creates the "sprint" command group
+func newSprintCmd() *cobra.Command {
+	sprintCmd := &cobra.Command{
+		Use:   "sprint",
+		Short: "Plan work in sprints (iterations)",
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a sprint",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSprintCreate,
+	}
+	createCmd.Flags().String("start", "", "Start date (YYYY-MM-DD, default today)")
+	createCmd.Flags().String("end", "", "End date, inclusive (YYYY-MM-DD, default two weeks after start)")
+	sprintCmd.AddCommand(createCmd)
+
+	sprintCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List sprints",
+		Args:  cobra.NoArgs,
+		RunE:  runSprintList,
+	})
+
+	sprintCmd.AddCommand(&cobra.Command{
+		Use:   "assign <task-id> <sprint|backlog>",
+		Short: "Assign a task to a sprint, or move it back to the backlog",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runSprintAssign,
+	})
+
+	sprintCmd.AddCommand(&cobra.Command{
+		Use:   "show <sprint>",
+		Short: "Show a sprint's tasks and what was carried over",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSprintShow,
+	})
+
+	closeCmd := &cobra.Command{
+		Use:   "close [sprint]",
+		Short: "Close a sprint, carrying unfinished tasks over to the next sprint",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runSprintClose,
+	}
+	closeCmd.Flags().String("next", "", "Sprint to carry unfinished tasks into (default: next sprint by start date)")
+	sprintCmd.AddCommand(closeCmd)
+
+	return sprintCmd
+}
+
+func runSprintCreate(cmd *cobra.Command, args []string) error {
+	startStr, _ := cmd.Flags().GetString("start")
+	endStr, _ := cmd.Flags().GetString("end")
+
+	start := time.Now()
+	if startStr != "" {
+		t, err := parseDateFlag(startStr)
+		if err != nil {
+			return fmt.Errorf("invalid --start: %w", err)
+		}
+		start = t
+	}
+	end := start.AddDate(0, 0, defaultSprintDays-1)
+	if endStr != "" {
+		t, err := parseDateFlag(endStr)
+		if err != nil {
+			return fmt.Errorf("invalid --end: %w", err)
+		}
+		end = t
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	sprint, err := database.CreateSprint(args[0], start, end)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created sprint %d %q (%s → %s)\n", sprint.ID, sprint.Name,
+		sprint.Start.Format("2006-01-02"), sprint.End.Format("2006-01-02"))
+	return nil
+}
+
+func runSprintList(cmd *cobra.Command, args []string) error {
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	sprints, err := database.GetSprints()
+	if err != nil {
+		return err
+	}
+	tasks, err := database.GetAllTasks()
+	if err != nil {
+		return err
+	}
+
+	done := make(map[int64]int)
+	total := make(map[int64]int)
+	for _, t := range tasks {
+		if t.SprintID == nil {
+			continue
+		}
+		total[*t.SprintID]++
+		if t.Status == model.StatusDone {
+			done[*t.SprintID]++
+		}
+	}
+
+	current := db.CurrentSprint(sprints, time.Now())
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tSTART\tEND\tSTATE\tDONE")
+	for _, s := range sprints {
+		state := "open"
+		if s.Closed() {
+			state = "closed"
+		} else if current != nil && current.ID == s.ID {
+			state = "current"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d/%d\n", s.ID, s.Name,
+			s.Start.Format("2006-01-02"), s.End.Format("2006-01-02"), state, done[s.ID], total[s.ID])
+	}
+	return w.Flush()
+}
+
+func runSprintAssign(cmd *cobra.Command, args []string) error {
+	taskID, err := parseTaskID(args[0])
+	if err != nil {
+		return err
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if isBacklogRef(args[1]) {
+		if err := database.AssignTaskSprint(taskID, nil); err != nil {
+			return err
+		}
+		fmt.Printf("Moved task %d to the backlog\n", taskID)
+		return nil
+	}
+
+	sprint, err := database.ResolveSprint(args[1])
+	if err != nil {
+		return err
+	}
+	if err := database.AssignTaskSprint(taskID, &sprint.ID); err != nil {
+		return err
+	}
+	fmt.Printf("Assigned task %d to sprint %q\n", taskID, sprint.Name)
+	return nil
+}
+
+func runSprintShow(cmd *cobra.Command, args []string) error {
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	sprint, err := database.ResolveSprint(args[0])
+	if err != nil {
+		return err
+	}
+	tasks, err := database.GetAllTasks()
+	if err != nil {
+		return err
+	}
+	carryovers, err := database.GetCarryovers(sprint.ID)
+	if err != nil {
+		return err
+	}
+
+	state := "open"
+	if sprint.Closed() {
+		state = "closed " + sprint.ClosedAt.Local().Format("2006-01-02 15:04")
+	}
+	fmt.Printf("Sprint %d %q (%s → %s, %s)\n\n", sprint.ID, sprint.Name,
+		sprint.Start.Format("2006-01-02"), sprint.End.Format("2006-01-02"), state)
+
+	if err := printTasks(filterTasksBySprint(tasks, sprint.ID)); err != nil {
+		return err
+	}
+
+	if len(carryovers) > 0 {
+		titles := make(map[int64]string, len(tasks))
+		for _, t := range tasks {
+			titles[t.ID] = t.Title
+		}
+		fmt.Printf("\nCarried over to the next sprint (%d):\n", len(carryovers))
+		for _, c := range carryovers {
+			fmt.Printf("  #%d %s\n", c.TaskID, titles[c.TaskID])
+		}
+	}
+	return nil
+}
+
+func runSprintClose(cmd *cobra.Command, args []string) error {
+	nextRef, _ := cmd.Flags().GetString("next")
+	ref := "current"
+	if len(args) == 1 {
+		ref = args[0]
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	sprint, err := database.ResolveSprint(ref)
+	if err != nil {
+		return err
+	}
+
+	var next *model.Sprint
+	if nextRef != "" {
+		if next, err = database.ResolveSprint(nextRef); err != nil {
+			return err
+		}
+	} else {
+		sprints, err := database.GetSprints()
+		if err != nil {
+			return err
+		}
+		next = db.NextSprint(sprints, *sprint)
+		if next == nil {
+			return fmt.Errorf("no sprint after %q to carry tasks into; create one or pass --next", sprint.Name)
+		}
+	}
+
+	carried, err := database.CloseSprint(sprint.ID, next.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Closed sprint %q; carried %d unfinished task(s) over to %q\n", sprint.Name, len(carried), next.Name)
+	return nil
+}
+
+// isBacklogRef reports whether a sprint argument refers to the backlog (no sprint)
+func isBacklogRef(ref string) bool {
+	switch strings.ToLower(strings.TrimSpace(ref)) {
+	case "backlog", "none":
+		return true
+	}
+	return false
+}
+
+// filterTasksBySprint returns the tasks assigned to the given sprint.
+// A sprintID of 0 selects backlog tasks that have no sprint.
+func filterTasksBySprint(tasks []model.Task, sprintID int64) []model.Task {
+	var filtered []model.Task
+	for _, t := range tasks {
+		if sprintID == 0 && t.SprintID == nil || t.SprintID != nil && *t.SprintID == sprintID {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
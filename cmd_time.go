@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/spf13/cobra"
+)
+
+// newTimeCmd creates the "time" command group for time tracking
+func newTimeCmd() *cobra.Command {
+	timeCmd := &cobra.Command{
+		Use:   "time",
+		Short: "Track time spent on tasks",
+	}
+
+	timeCmd.AddCommand(&cobra.Command{
+		Use:   "start <task-id>",
+		Short: "Start a timer on a task (stops any running timer)",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runTimeStart,
+	})
+
+	stopCmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the running timer",
+		Args:  cobra.NoArgs,
+		RunE:  runTimeStop,
+	}
+	stopCmd.Flags().String("note", "", "Note to attach to the time entry")
+	timeCmd.AddCommand(stopCmd)
+
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Summarize tracked time and completed pomodoros",
+		Args:  cobra.NoArgs,
+		RunE:  runTimeReport,
+	}
+	reportCmd.Flags().String("since", "", "Only include entries started on or after this date (YYYY-MM-DD)")
+	reportCmd.Flags().String("by", "task", "Group by: task, tag or day")
+	timeCmd.AddCommand(reportCmd)
+
+	return timeCmd
+}
+
+func runTimeStart(cmd *cobra.Command, args []string) error {
+	id, err := parseTaskID(args[0])
+	if err != nil {
+		return err
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	entry, err := database.StartTimer(id)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Started timer on task %d at %s\n", id, entry.StartedAt.Format("15:04:05"))
+	return nil
+}
+
+func runTimeStop(cmd *cobra.Command, args []string) error {
+	note, _ := cmd.Flags().GetString("note")
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	running, err := database.GetRunningTimer()
+	if err != nil {
+		return err
+	}
+	if running == nil {
+		return fmt.Errorf("no timer is running")
+	}
+
+	entry, err := database.StopTimer(running.TaskID, note)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Stopped timer on task %d after %s\n", entry.TaskID, model.FormatTracked(entry.Duration(time.Now())))
+	return nil
+}
+
+func runTimeReport(cmd *cobra.Command, args []string) error {
+	sinceStr, _ := cmd.Flags().GetString("since")
+	by, _ := cmd.Flags().GetString("by")
+	if by != "task" && by != "tag" && by != "day" {
+		return fmt.Errorf("invalid --by %q (use task, tag or day)", by)
+	}
+
+	var since time.Time
+	if sinceStr != "" {
+		t, err := parseDateFlag(sinceStr)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		since = t
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	entries, err := database.GetTimeEntries(since)
+	if err != nil {
+		return err
+	}
+	pomodoros, err := database.GetPomodoros(since)
+	if err != nil {
+		return err
+	}
+	tasks, err := database.GetAllTasks()
+	if err != nil {
+		return err
+	}
+	tasksByID := make(map[int64]model.Task, len(tasks))
+	for _, t := range tasks {
+		tasksByID[t.ID] = t
+	}
+
+	now := time.Now()
+	totals := make(map[string]time.Duration)
+	counts := make(map[string]int)
+	tomatoes := make(map[string]int)
+	var grand time.Duration
+	for _, e := range entries {
+		d := e.Duration(now)
+		grand += d
+		for _, key := range reportKeys(by, e.TaskID, e.StartedAt, tasksByID) {
+			totals[key] += d
+			counts[key]++
+		}
+	}
+	for _, p := range pomodoros {
+		for _, key := range reportKeys(by, p.TaskID, p.CompletedAt, tasksByID) {
+			if _, ok := totals[key]; !ok {
+				totals[key] = 0
+			}
+			tomatoes[key]++
+		}
+	}
+
+	keys := make([]string, 0, len(totals))
+	for k := range totals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if by == "day" {
+			return keys[i] < keys[j]
+		}
+		if totals[keys[i]] != totals[keys[j]] {
+			return totals[keys[i]] > totals[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tTIME\tENTRIES\tPOMODOROS\n", map[string]string{"task": "TASK", "tag": "TAG", "day": "DAY"}[by])
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", k, model.FormatTracked(totals[k]), counts[k], tomatoes[k])
+	}
+	fmt.Fprintf(w, "TOTAL\t%s\t%d\t%d\n", model.FormatTracked(grand), len(entries), len(pomodoros))
+	return w.Flush()
+}
+
+// reportKeys returns the groups a record of a task at the given time is counted under
+func reportKeys(by string, taskID int64, at time.Time, tasks map[int64]model.Task) []string {
+	switch by {
+	case "day":
+		return []string{at.Local().Format("2006-01-02")}
+	case "tag":
+		task, ok := tasks[taskID]
+		if !ok || len(task.Tags) == 0 {
+			return []string{"(untagged)"}
+		}
+		return task.Tags
+	case "task":
+		if task, ok := tasks[taskID]; ok {
+			return []string{fmt.Sprintf("#%d %s", task.ID, task.Title)}
+		}
+		return []string{fmt.Sprintf("#%d (deleted)", taskID)}
+	}
+	return nil
+}
+
+// parseTaskID parses a task ID argument
+func parseTaskID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid task id %q", s)
+	}
+	return id, nil
+}
+
+// parseDateFlag parses a YYYY-MM-DD flag value as local midnight
+func parseDateFlag(s string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", s, time.Local)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/query"
+	"github.com/spf13/cobra"
+)
+
+// newViewCmd creates the "view" command group for saved searches
+func newViewCmd() *cobra.Command {
+	viewCmd := &cobra.Command{
+		Use:   "view",
+		Short: "Manage saved views (named search filters)",
+	}
+
+	saveCmd := &cobra.Command{
+		Use:   "save <name> <query>",
+		Short: "Save a view, replacing any view with the same name",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runViewSave,
+	}
+	saveCmd.Flags().String("sort", "", "Sort tasks by created, updated, due, estimate, title or age")
+	saveCmd.Flags().String("columns", "", "Comma-separated columns to show (todo, in_progress, done; default all)")
+	viewCmd.AddCommand(saveCmd)
+
+	viewCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List saved views",
+		Args:  cobra.NoArgs,
+		RunE:  runViewList,
+	})
+
+	viewCmd.AddCommand(&cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a saved view",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runViewDelete,
+	})
+
+	return viewCmd
+}
+
+func runViewSave(cmd *cobra.Command, args []string) error {
+	sortStr, _ := cmd.Flags().GetString("sort")
+	columnsStr, _ := cmd.Flags().GetString("columns")
+
+	if _, err := query.Parse(args[1]); err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+	sort, err := model.ParseViewSort(sortStr)
+	if err != nil {
+		return err
+	}
+	var columns []model.TaskStatus
+	for _, c := range strings.Split(columnsStr, ",") {
+		if c = strings.TrimSpace(c); c == "" {
+			continue
+		}
+		status, err := model.ParseStatus(c)
+		if err != nil {
+			return err
+		}
+		columns = append(columns, status)
+	}
+
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	view, err := database.SaveView(model.SavedView{Name: args[0], Query: args[1], Sort: sort, Columns: columns})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Saved view %q\n", view.Name)
+	return nil
+}
+
+func runViewList(cmd *cobra.Command, args []string) error {
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	views, err := database.GetViews()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tNAME\tQUERY\tSORT\tCOLUMNS")
+	for i, v := range views {
+		key := "-"
+		if i < 9 {
+			key = fmt.Sprint(i + 1)
+		}
+		columns := "all"
+		if len(v.Columns) > 0 {
+			names := make([]string, len(v.Columns))
+			for j, c := range v.Columns {
+				names[j] = string(c)
+			}
+			columns = strings.Join(names, ",")
+		}
+		sort := string(v.Sort)
+		if sort == "" {
+			sort = string(model.SortCreated)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", key, v.Name, v.Query, sort, columns)
+	}
+	return w.Flush()
+}
+
+func runViewDelete(cmd *cobra.Command, args []string) error {
+	database, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err := database.DeleteView(args[0]); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted view %q\n", args[0])
+	return nil
+}
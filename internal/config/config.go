@@ -0,0 +1,182 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/theme"
+)
+
+// Config holds user settings loaded from the config file
+type Config struct {
+	Estimate  EstimateConfig `toml:"estimate"`
+	Aging     AgingConfig    `toml:"aging"`
+	Theme     ThemeConfig    `toml:"theme"`
+	Display   DisplayConfig  `toml:"display"`
+	Due       DueConfig      `toml:"due"`
+	Reminders ReminderConfig `toml:"reminders"`
+	// Keys maps action names to the keys that trigger them, replacing
+	// the defaults, see Keymap
+	Keys map[string]KeyList `toml:"keys"`
+}
+
+// EstimateConfig configures task estimates
+type EstimateConfig struct {
+	// Unit is "points" or "hours"
+	Unit model.EstimateUnit `toml:"unit"`
+}
+
+// AgingConfig configures the time-in-column indicator on cards
+type AgingConfig struct {
+	// WarnDays and AlertDays are the ages at which the indicator turns
+	// amber and red
+	WarnDays  int `toml:"warn_days"`
+	AlertDays int `toml:"alert_days"`
+}
+
+// validate checks the thresholds, naming the keys the file set: a
+// threshold left at its default is reported as such
+func (a AgingConfig) validate(md toml.MetaData) error {
+	if a.WarnDays < 0 {
+		return fmt.Errorf("aging.warn_days must not be negative, got %d", a.WarnDays)
+	}
+	if a.AlertDays < 1 {
+		return fmt.Errorf("aging.alert_days must be at least 1, got %d", a.AlertDays)
+	}
+	if a.AlertDays >= a.WarnDays {
+		return nil
+	}
+	warnSet := md.IsDefined("aging", "warn_days")
+	alertSet := md.IsDefined("aging", "alert_days")
+	switch {
+	case alertSet && !warnSet:
+		return fmt.Errorf("aging.alert_days (%d) must not be less than the default aging.warn_days (%d); set warn_days too", a.AlertDays, a.WarnDays)
+	case warnSet && !alertSet:
+		return fmt.Errorf("aging.warn_days (%d) must not be more than the default aging.alert_days (%d); set alert_days too", a.WarnDays, a.AlertDays)
+	}
+	return fmt.Errorf("aging.alert_days (%d) must not be less than aging.warn_days (%d)", a.AlertDays, a.WarnDays)
+}
+
+// ThemeConfig selects the board's colors
+type ThemeConfig struct {
+	// Name is a built-in theme or "auto", see theme.Names
+	Name string `toml:"name"`
+	// Columns overrides column colors by status, e.g. todo = "#888888"
+	Columns map[string]string `toml:"columns"`
+}
+
+// DisplayConfig configures what cards show
+type DisplayConfig struct {
+	// Excerpt shows the first line of the description under card titles
+	Excerpt bool `toml:"excerpt"`
+}
+
+// DueConfig configures the due date colors on cards
+type DueConfig struct {
+	// SoonDays is how many days after today a due date is colored as
+	// soon; overdue and today are always colored
+	SoonDays int `toml:"soon_days"`
+}
+
+// ReminderConfig configures reminders for due dates with a time of day
+type ReminderConfig struct {
+	// Before lists how long before the due time reminders fire, e.g.
+	// ["1h", "15m"]; "0m" fires at the due time and [] turns them off
+	Before []Duration `toml:"before"`
+	// Bell rings the terminal bell when a reminder fires
+	Bell bool `toml:"bell"`
+}
+
+// Duration is a time.Duration written as a string like "1h30m" in the
+// config file
+type Duration time.Duration
+
+// UnmarshalText parses a duration string
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Default returns the built-in configuration used when no file exists
+func Default() Config {
+	return Config{
+		Estimate: EstimateConfig{Unit: model.EstimatePoints},
+		Aging:    AgingConfig{WarnDays: 3, AlertDays: 7},
+		Theme:    ThemeConfig{Name: theme.Auto},
+		Due:      DueConfig{SoonDays: 3},
+		Reminders: ReminderConfig{
+			Before: []Duration{Duration(15 * time.Minute), 0},
+			Bell:   true,
+		},
+	}
+}
+
+// DefaultPath returns ~/.config/cli_kanban/config.toml, honoring XDG_CONFIG_HOME
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "cli_kanban", "config.toml")
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		homeDir = "."
+	}
+	return filepath.Join(homeDir, ".config", "cli_kanban", "config.toml")
+}
+
+// Load reads the config file at path on top of the defaults.
+// A missing file is not an error.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	md, err := toml.Decode(string(data), &cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if err := cfg.validate(md); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// validate checks the loaded values; md tells which keys the file set
+func (c Config) validate(md toml.MetaData) error {
+	switch c.Estimate.Unit {
+	case model.EstimatePoints, model.EstimateHours:
+	default:
+		return fmt.Errorf("estimate.unit must be %q or %q, got %q", model.EstimatePoints, model.EstimateHours, c.Estimate.Unit)
+	}
+	if err := c.Aging.validate(md); err != nil {
+		return err
+	}
+	if c.Due.SoonDays < 0 {
+		return fmt.Errorf("due.soon_days must not be negative")
+	}
+	for _, d := range c.Reminders.Before {
+		if d < 0 {
+			return fmt.Errorf("reminders.before must not be negative, got %s", time.Duration(d))
+		}
+	}
+	if err := theme.Validate(c.Theme.Name, c.Theme.Columns); err != nil {
+		return fmt.Errorf("theme: %w", err)
+	}
+	return c.validateKeys()
+}
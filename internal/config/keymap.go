@@ -0,0 +1,231 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Key binding contexts: bindings conflict within the same context, and
+// with the bindings of other contexts a full-screen view also reads, see
+// screenBindings.
+const (
+	ContextBoard    = "board"    // keys on the board
+	ContextInput    = "input"    // keys in text inputs and prompts
+	ContextConfirm  = "confirm"  // keys in yes/no confirmations
+	ContextFocus    = "focus"    // keys in focus mode
+	ContextSprints  = "sprints"  // keys in the sprint switcher
+	ContextViews    = "views"    // keys in the saved view picker
+	ContextDetail   = "detail"   // keys in the task details view
+	ContextCalendar = "calendar" // keys in the calendar
+)
+
+// screenBindings lists, for each full-screen view and the confirmation
+// prompt, the bindings of other contexts it reads besides its own: the
+// board's navigation keys, input's submit and cancel, and the key that
+// opened the view or quit to close it
+var screenBindings = map[string][]string{
+	ContextConfirm:  {"cancel"},
+	ContextFocus:    {"cancel", "quit", "focus"},
+	ContextSprints:  {"submit", "cancel", "up", "down", "quit", "sprints"},
+	ContextViews:    {"submit", "cancel", "up", "down", "quit", "views"},
+	ContextDetail:   {"cancel", "up", "down", "description", "quit", "detail"},
+	ContextCalendar: {"submit", "cancel", "left", "right", "up", "down", "quit", "calendar"},
+}
+
+// Binding is a named action and the keys that trigger it
+type Binding struct {
+	Name    string // name used in the [keys] table, e.g. "delete"
+	Context string
+	Group   string // help view section
+	Help    string
+	Keys    []string // key names as reported by the terminal, e.g. "ctrl+d"
+}
+
+// defaultBindings lists every configurable action in help view order
+var defaultBindings = []Binding{
+	{Name: "left", Context: ContextBoard, Group: "Navigation", Help: "Move to the column on the left", Keys: []string{"left", "h"}},
+	{Name: "right", Context: ContextBoard, Group: "Navigation", Help: "Move to the column on the right", Keys: []string{"right", "l"}},
+	{Name: "up", Context: ContextBoard, Group: "Navigation", Help: "Select the task above", Keys: []string{"up", "k"}},
+	{Name: "down", Context: ContextBoard, Group: "Navigation", Help: "Select the task below", Keys: []string{"down", "j"}},
+
+	{Name: "add", Context: ContextBoard, Group: "Actions", Help: "Add new task to current column", Keys: []string{"a"}},
+	{Name: "edit", Context: ContextBoard, Group: "Actions", Help: "Edit selected task title", Keys: []string{"e", "enter"}},
+	{Name: "description", Context: ContextBoard, Group: "Actions", Help: "Edit selected task description", Keys: []string{"i"}},
+	{Name: "editor", Context: ContextBoard, Group: "Actions", Help: "Edit all fields of the selected task in $VISUAL or $EDITOR", Keys: []string{"ctrl+e"}},
+	{Name: "tags", Context: ContextBoard, Group: "Actions", Help: "Edit selected task tags", Keys: []string{"t"}},
+	{Name: "due", Context: ContextBoard, Group: "Actions", Help: "Edit selected task due date", Keys: []string{"u"}},
+	{Name: "estimate", Context: ContextBoard, Group: "Actions", Help: "Edit selected task estimate", Keys: []string{"E"}},
+	{Name: "delete", Context: ContextBoard, Group: "Actions", Help: "Delete selected task", Keys: []string{"d", "delete"}},
+	{Name: "move", Context: ContextBoard, Group: "Actions", Help: "Move task to next column", Keys: []string{"m"}},
+	{Name: "archive", Context: ContextBoard, Group: "Actions", Help: "Archive selected task (hidden from the board)", Keys: []string{"X"}},
+	{Name: "timer", Context: ContextBoard, Group: "Actions", Help: "Start/stop timer on selected task", Keys: []string{"s"}},
+	{Name: "focus", Context: ContextBoard, Group: "Actions", Help: "Focus on selected task (pomodoro 25/5)", Keys: []string{"f"}},
+	{Name: "sprints", Context: ContextBoard, Group: "Actions", Help: "Sprint switcher (scope the board or assign the selected task)", Keys: []string{"S"}},
+	{Name: "stats", Context: ContextBoard, Group: "Actions", Help: "Stats: burndown and cumulative flow charts", Keys: []string{"r"}},
+	{Name: "calendar", Context: ContextBoard, Group: "Actions", Help: "Calendar of tasks by due date (reschedule by moving a task to another day)", Keys: []string{"c"}},
+	{Name: "detail", Context: ContextBoard, Group: "Actions", Help: "Show all details of the selected task (read-only)", Keys: []string{"o"}},
+	{Name: "detail_pane", Context: ContextBoard, Group: "Actions", Help: "Toggle the detail pane beside the board", Keys: []string{"p"}},
+
+	{Name: "select", Context: ContextBoard, Group: "Selection", Help: "Select or unselect the task and move down", Keys: []string{" "}},
+	{Name: "select_range", Context: ContextBoard, Group: "Selection", Help: "Select every task from the last selected one to the cursor", Keys: []string{"V"}},
+	{Name: "select_column", Context: ContextBoard, Group: "Selection", Help: "Select (or unselect) every task in the column", Keys: []string{"A"}},
+	{Name: "select_all", Context: ContextBoard, Group: "Selection", Help: "Select every task matching the filter", Keys: []string{"ctrl+a"}},
+
+	{Name: "search", Context: ContextBoard, Group: "Find", Help: "Open search input (filters as you type)", Keys: []string{"/"}},
+	{Name: "clear_filter", Context: ContextBoard, Group: "Find", Help: "Dismiss reminders, clear the selection, leave the active view or clear the filter, then quit", Keys: []string{"esc"}},
+	{Name: "filter_overdue", Context: ContextBoard, Group: "Find", Help: "Show only unfinished overdue tasks; again to clear", Keys: []string{"!"}},
+	{Name: "filter_due_today", Context: ContextBoard, Group: "Find", Help: "Show only unfinished tasks due later today; again to clear", Keys: []string{"T"}},
+	{Name: "find", Context: ContextBoard, Group: "Find", Help: "Fuzzy find any task by title, tag or #ID", Keys: []string{"ctrl+p"}},
+	{Name: "palette", Context: ContextBoard, Group: "Find", Help: "Command palette: run any action by name", Keys: []string{":"}},
+	{Name: "views", Context: ContextBoard, Group: "Find", Help: "View picker (apply, save or delete views)", Keys: []string{"v"}},
+
+	{Name: "refresh", Context: ContextBoard, Group: "Other", Help: "Refresh board", Keys: []string{"f5"}},
+	{Name: "help", Context: ContextBoard, Group: "Other", Help: "Show this help", Keys: []string{"?"}},
+	{Name: "quit", Context: ContextBoard, Group: "Other", Help: "Quit application", Keys: []string{"q", "ctrl+c"}},
+
+	{Name: "submit", Context: ContextInput, Group: "Editing", Help: "Save input", Keys: []string{"enter"}},
+	{Name: "cancel", Context: ContextInput, Group: "Editing", Help: "Cancel current action", Keys: []string{"esc"}},
+	{Name: "save_description", Context: ContextInput, Group: "Editing", Help: "Save description (Enter adds a new line)", Keys: []string{"ctrl+s"}},
+	{Name: "prev", Context: ContextInput, Group: "Editing", Help: "Previous result in the finder and command palette", Keys: []string{"up", "ctrl+k", "ctrl+p"}},
+	{Name: "next", Context: ContextInput, Group: "Editing", Help: "Next result in the finder and command palette", Keys: []string{"down", "ctrl+j", "ctrl+n"}},
+	{Name: "complete", Context: ContextInput, Group: "Editing", Help: "Complete the highlighted command to type its argument", Keys: []string{"tab"}},
+	{Name: "confirm", Context: ContextConfirm, Group: "Editing", Help: "Confirm deletion", Keys: []string{"y", "Y"}},
+	{Name: "deny", Context: ContextConfirm, Group: "Editing", Help: "Keep the task", Keys: []string{"n", "N"}},
+
+	{Name: "focus_pause", Context: ContextFocus, Group: "Focus", Help: "Pause or resume the countdown", Keys: []string{" ", "p"}},
+	{Name: "focus_skip", Context: ContextFocus, Group: "Focus", Help: "Skip to the next interval", Keys: []string{"n"}},
+
+	{Name: "sprint_assign", Context: ContextSprints, Group: "Pickers", Help: "Sprint switcher: assign the selected task to the highlighted sprint", Keys: []string{"a"}},
+	{Name: "view_save", Context: ContextViews, Group: "Pickers", Help: "View picker: save the current filter as a view", Keys: []string{"n"}},
+	{Name: "view_delete", Context: ContextViews, Group: "Pickers", Help: "View picker: delete the highlighted view", Keys: []string{"d"}},
+
+	{Name: "page_up", Context: ContextDetail, Group: "Details", Help: "Scroll up a page", Keys: []string{"pgup"}},
+	{Name: "page_down", Context: ContextDetail, Group: "Details", Help: "Scroll down a page", Keys: []string{"pgdown", " "}},
+	{Name: "top", Context: ContextDetail, Group: "Details", Help: "Scroll to the top", Keys: []string{"home"}},
+	{Name: "bottom", Context: ContextDetail, Group: "Details", Help: "Scroll to the bottom", Keys: []string{"end"}},
+
+	{Name: "calendar_prev", Context: ContextCalendar, Group: "Calendar", Help: "Previous month (week in the week layout)", Keys: []string{"[", "pgup"}},
+	{Name: "calendar_next", Context: ContextCalendar, Group: "Calendar", Help: "Next month (week in the week layout)", Keys: []string{"]", "pgdown"}},
+	{Name: "calendar_week", Context: ContextCalendar, Group: "Calendar", Help: "Switch between the month and week layouts", Keys: []string{"w"}},
+	{Name: "calendar_today", Context: ContextCalendar, Group: "Calendar", Help: "Go to today", Keys: []string{"t"}},
+	{Name: "calendar_move", Context: ContextCalendar, Group: "Calendar", Help: "Pick up the day's task to move it to another day", Keys: []string{"m"}},
+}
+
+// KeyList is one or more key names; the config file accepts a single
+// string or an array
+type KeyList []string
+
+// UnmarshalTOML decodes a string or an array of strings
+func (k *KeyList) UnmarshalTOML(v interface{}) error {
+	switch v := v.(type) {
+	case string:
+		*k = KeyList{v}
+	case []interface{}:
+		keys := make(KeyList, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("key names must be strings, got %v", item)
+			}
+			keys = append(keys, s)
+		}
+		*k = keys
+	default:
+		return fmt.Errorf("expected a key name or an array of key names, got %v", v)
+	}
+	return nil
+}
+
+// Keymap returns every binding with the keys set in the config file
+// replacing the defaults
+func (c Config) Keymap() []Binding {
+	bindings := make([]Binding, len(defaultBindings))
+	for i, b := range defaultBindings {
+		if keys, ok := c.Keys[b.Name]; ok {
+			b.Keys = append([]string(nil), keys...)
+		}
+		bindings[i] = b
+	}
+	return bindings
+}
+
+// validateKeys checks the [keys] table names real actions and that no key
+// triggers two actions in the same context
+func (c Config) validateKeys() error {
+	known := make(map[string]bool, len(defaultBindings))
+	for _, b := range defaultBindings {
+		known[b.Name] = true
+	}
+
+	var names []string
+	for name := range c.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !known[name] {
+			return fmt.Errorf("keys.%s: unknown action", name)
+		}
+		if len(c.Keys[name]) == 0 {
+			return fmt.Errorf("keys.%s: no keys given", name)
+		}
+		for _, key := range c.Keys[name] {
+			if key == "" {
+				return fmt.Errorf("keys.%s: empty key name", name)
+			}
+		}
+	}
+
+	keymap := c.Keymap()
+	byName := make(map[string]Binding, len(keymap))
+	for _, b := range keymap {
+		byName[b.Name] = b
+		if b.Context != ContextInput {
+			continue
+		}
+		// Text inputs take printable characters as typed text
+		for _, key := range b.Keys {
+			if r, size := utf8.DecodeRuneInString(key); size == len(key) && unicode.IsPrint(r) {
+				return fmt.Errorf("keys.%s: %q would stop it being typed in text inputs", b.Name, key)
+			}
+		}
+	}
+
+	bound := make(map[string]string) // context and key -> action
+	for _, b := range keymap {
+		for _, key := range b.Keys {
+			id := b.Context + " " + key
+			if other, ok := bound[id]; ok && other != b.Name {
+				return fmt.Errorf("key %q is bound to both %s and %s", key, other, b.Name)
+			}
+			bound[id] = b.Name
+		}
+	}
+
+	contexts := make([]string, 0, len(screenBindings))
+	for context := range screenBindings {
+		contexts = append(contexts, context)
+	}
+	sort.Strings(contexts)
+	for _, context := range contexts {
+		read := make(map[string]string) // key -> action
+		for _, b := range keymap {
+			if b.Context == context {
+				for _, key := range b.Keys {
+					read[key] = b.Name
+				}
+			}
+		}
+		for _, name := range screenBindings[context] {
+			for _, key := range byName[name].Keys {
+				if other, ok := read[key]; ok && other != name {
+					return fmt.Errorf("key %q is bound to both %s and %s, both read in the %s view", key, other, name, context)
+				}
+				read[key] = name
+			}
+		}
+	}
+	return nil
+}
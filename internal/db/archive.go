@@ -0,0 +1,51 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// ArchiveTask moves a task off the board; its history is kept for reports
+func (db *DB) ArchiveTask(id int64) error {
+	now := time.Now()
+	result, err := db.conn.Exec(
+		"UPDATE tasks SET archived_at = ?, updated_at = ? WHERE id = ? AND archived_at IS NULL",
+		now, now, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to archive task: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("task not found or already archived")
+	}
+
+	return nil
+}
+
+// UnarchiveTask puts an archived task back on the board
+func (db *DB) UnarchiveTask(id int64) error {
+	result, err := db.conn.Exec(
+		"UPDATE tasks SET archived_at = NULL, updated_at = ? WHERE id = ? AND archived_at IS NOT NULL",
+		time.Now(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unarchive task: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("task not found or not archived")
+	}
+
+	return nil
+}
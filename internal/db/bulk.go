@@ -0,0 +1,170 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// Bulk operations change several tasks in a single transaction: if any task
+// fails, none of them change.
+
+// inTransaction runs fn in a transaction, committing it when fn succeeds
+func (db *DB) inTransaction(fn func(tx *sql.Tx) error) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// MoveTasks moves tasks to a status, recording each status change
+func (db *DB) MoveTasks(ids []int64, status model.TaskStatus) error {
+	now := time.Now()
+	return db.inTransaction(func(tx *sql.Tx) error {
+		for _, id := range ids {
+			if err := setStatus(tx, id, status, now); err != nil {
+				return fmt.Errorf("task %d: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteTasks deletes tasks along with their time entries and pomodoros;
+// their status history ends with the deletion
+func (db *DB) DeleteTasks(ids []int64) error {
+	return db.inTransaction(func(tx *sql.Tx) error {
+		for _, id := range ids {
+			if err := deleteTask(tx, id); err != nil {
+				return fmt.Errorf("task %d: %w", id, err)
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateTasksTags adds and removes tags on tasks, keeping their other tags
+func (db *DB) UpdateTasksTags(ids []int64, add, remove []string) error {
+	removed := make(map[string]bool, len(remove))
+	for _, tag := range parseTags(tagsToString(remove)) {
+		removed[tag] = true
+	}
+
+	now := time.Now()
+	return db.inTransaction(func(tx *sql.Tx) error {
+		for _, id := range ids {
+			var current string
+			err := tx.QueryRow("SELECT tags FROM tasks WHERE id = ?", id).Scan(&current)
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("task %d: task not found", id)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read task tags: %w", err)
+			}
+
+			var tags []string
+			for _, tag := range append(parseTags(current), parseTags(tagsToString(add))...) {
+				if !removed[tag] {
+					tags = append(tags, tag)
+				}
+			}
+			if _, err := tx.Exec(
+				"UPDATE tasks SET tags = ?, updated_at = ? WHERE id = ?",
+				tagsToString(tags), now, id,
+			); err != nil {
+				return fmt.Errorf("failed to update task tags: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// SetTasksDue sets the due date of tasks; nil clears it
+func (db *DB) SetTasksDue(ids []int64, due *model.Due) error {
+	now := time.Now()
+	value, allDay := dueValue(due)
+	return db.inTransaction(func(tx *sql.Tx) error {
+		for _, id := range ids {
+			result, err := tx.Exec(
+				"UPDATE tasks SET due = ?, due_all_day = ?, updated_at = ? WHERE id = ?",
+				value, allDay, now, id,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to update task due: %w", err)
+			}
+			if err := expectRow(result, id, "task not found"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetTasksEstimate sets or clears (nil) the estimate of tasks
+func (db *DB) SetTasksEstimate(ids []int64, estimate *float64) error {
+	var value interface{}
+	if estimate != nil {
+		value = *estimate
+	}
+	now := time.Now()
+	return db.inTransaction(func(tx *sql.Tx) error {
+		for _, id := range ids {
+			result, err := tx.Exec(
+				"UPDATE tasks SET estimate = ?, updated_at = ? WHERE id = ?",
+				value, now, id,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to update task estimate: %w", err)
+			}
+			if err := expectRow(result, id, "task not found"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ArchiveTasks moves tasks off the board; their history is kept for reports
+func (db *DB) ArchiveTasks(ids []int64) error {
+	now := time.Now()
+	return db.inTransaction(func(tx *sql.Tx) error {
+		for _, id := range ids {
+			result, err := tx.Exec(
+				"UPDATE tasks SET archived_at = ?, updated_at = ? WHERE id = ? AND archived_at IS NULL",
+				now, now, id,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to archive task: %w", err)
+			}
+			if err := expectRow(result, id, "task not found or already archived"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// expectRow returns an error naming the task when a statement changed no row
+func expectRow(result sql.Result, id int64, problem string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("task %d: %s", id, problem)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// setStatus changes a task's status inside tx, recording the transition if it changed
+func setStatus(tx *sql.Tx, id int64, status model.TaskStatus, now time.Time) error {
+	var current model.TaskStatus
+	err := tx.QueryRow("SELECT status FROM tasks WHERE id = ?", id).Scan(&current)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("task not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read task status: %w", err)
+	}
+
+	if _, err := tx.Exec("UPDATE tasks SET status = ?, updated_at = ? WHERE id = ?", status, now, id); err != nil {
+		return fmt.Errorf("failed to update task status: %w", err)
+	}
+
+	if current == status {
+		return nil
+	}
+	if _, err := tx.Exec("UPDATE tasks SET status_changed_at = ? WHERE id = ?", now, id); err != nil {
+		return fmt.Errorf("failed to update task status: %w", err)
+	}
+	return recordTransition(tx, id, current, status, now)
+}
+
+// recordTransition appends a status change to the task's history
+func recordTransition(tx *sql.Tx, id int64, from, to model.TaskStatus, at time.Time) error {
+	_, err := tx.Exec(
+		"INSERT INTO status_transitions (task_id, from_status, to_status, changed_at) VALUES (?, ?, ?, ?)",
+		id, from, to, at,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record status change: %w", err)
+	}
+	return nil
+}
+
+// backfillTransitions gives tasks without any history a single transition
+// into their current status at creation time
+func (db *DB) backfillTransitions() error {
+	_, err := db.conn.Exec(`
+		INSERT INTO status_transitions (task_id, from_status, to_status, changed_at)
+		SELECT id, '', status, created_at FROM tasks
+		WHERE id NOT IN (SELECT DISTINCT task_id FROM status_transitions)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill status history: %w", err)
+	}
+	return nil
+}
+
+// backfillStatusChangedAt sets status_changed_at from the latest recorded
+// transition for tasks created before the column existed
+func (db *DB) backfillStatusChangedAt() error {
+	_, err := db.conn.Exec(`
+		UPDATE tasks SET status_changed_at = COALESCE(
+			(SELECT changed_at FROM status_transitions st WHERE st.task_id = tasks.id ORDER BY st.id DESC LIMIT 1),
+			created_at
+		)
+		WHERE status_changed_at IS NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill status change times: %w", err)
+	}
+	return nil
+}
+
+// GetStatusTransitions retrieves the status history of all tasks, oldest first
+func (db *DB) GetStatusTransitions() ([]model.StatusTransition, error) {
+	rows, err := db.conn.Query(
+		"SELECT task_id, from_status, to_status, changed_at FROM status_transitions ORDER BY changed_at, id",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+	defer rows.Close()
+
+	var transitions []model.StatusTransition
+	for rows.Next() {
+		var t model.StatusTransition
+		if err := rows.Scan(&t.TaskID, &t.From, &t.To, &t.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		transitions = append(transitions, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read status history: %w", err)
+	}
+
+	// Stored timestamps may carry different UTC offsets; order by instant
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].ChangedAt.Before(transitions[j].ChangedAt)
+	})
+	return transitions, nil
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// LogPomodoro records a completed pomodoro against a task
+func (db *DB) LogPomodoro(taskID int64, startedAt, completedAt time.Time) (*model.Pomodoro, error) {
+	result, err := db.conn.Exec(
+		"INSERT INTO pomodoros (task_id, started_at, completed_at) VALUES (?, ?, ?)",
+		taskID, startedAt, completedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to log pomodoro: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return &model.Pomodoro{
+		ID:          id,
+		TaskID:      taskID,
+		StartedAt:   startedAt,
+		CompletedAt: completedAt,
+	}, nil
+}
+
+// GetPomodoros retrieves all pomodoros completed at or after since.
+// A zero since returns every pomodoro.
+func (db *DB) GetPomodoros(since time.Time) ([]model.Pomodoro, error) {
+	rows, err := db.conn.Query(
+		"SELECT id, task_id, started_at, completed_at FROM pomodoros ORDER BY completed_at",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pomodoros: %w", err)
+	}
+	defer rows.Close()
+
+	var pomodoros []model.Pomodoro
+	for rows.Next() {
+		var p model.Pomodoro
+		if err := rows.Scan(&p.ID, &p.TaskID, &p.StartedAt, &p.CompletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pomodoro: %w", err)
+		}
+		if !since.IsZero() && p.CompletedAt.Before(since) {
+			continue
+		}
+		pomodoros = append(pomodoros, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read pomodoros: %w", err)
+	}
+
+	return pomodoros, nil
+}
+
+// attachPomodoros fills in the completed pomodoro count of each task
+func (db *DB) attachPomodoros(tasks []model.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	rows, err := db.conn.Query("SELECT task_id, COUNT(*) FROM pomodoros GROUP BY task_id")
+	if err != nil {
+		return fmt.Errorf("failed to count pomodoros: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]int)
+	for rows.Next() {
+		var taskID int64
+		var count int
+		if err := rows.Scan(&taskID, &count); err != nil {
+			return fmt.Errorf("failed to scan pomodoro count: %w", err)
+		}
+		counts[taskID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read pomodoro counts: %w", err)
+	}
+
+	for i := range tasks {
+		tasks[i].Pomodoros = counts[tasks[i].ID]
+	}
+	return nil
+}
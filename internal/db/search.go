@@ -0,0 +1,328 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// searchTerm is one word or quoted phrase of a full-text query
+type searchTerm struct {
+	text   string
+	prefix bool // match words starting with text
+}
+
+// initSearch creates the FTS5 index over task text and the triggers keeping
+// it in sync. SQLite builds without FTS5 fall back to LIKE scans.
+func (db *DB) initSearch() error {
+	// The triggers may be missing on a new database, or because a build
+	// without FTS5 dropped them; either way the index must be rebuilt
+	var synced int
+	if err := db.conn.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name = 'tasks_fts_insert'",
+	).Scan(&synced); err != nil {
+		return fmt.Errorf("failed to check search index: %w", err)
+	}
+
+	// Probe for the module; an existing tasks_fts table says nothing about
+	// whether this build can use it
+	if _, err := db.conn.Exec("CREATE VIRTUAL TABLE temp.fts5_probe USING fts5(x); DROP TABLE temp.fts5_probe;"); err != nil {
+		if strings.Contains(err.Error(), "no such module") {
+			return db.dropSearchTriggers()
+		}
+		return fmt.Errorf("failed to check for FTS5: %w", err)
+	}
+
+	_, err := db.conn.Exec(`
+		CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+			title, description, tags,
+			content='tasks', content_rowid='id'
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+
+	triggers := `
+	CREATE TRIGGER IF NOT EXISTS tasks_fts_insert AFTER INSERT ON tasks BEGIN
+		INSERT INTO tasks_fts(rowid, title, description, tags)
+		VALUES (new.id, new.title, new.description, new.tags);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS tasks_fts_delete AFTER DELETE ON tasks BEGIN
+		INSERT INTO tasks_fts(tasks_fts, rowid, title, description, tags)
+		VALUES ('delete', old.id, old.title, old.description, old.tags);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS tasks_fts_update AFTER UPDATE OF title, description, tags ON tasks BEGIN
+		INSERT INTO tasks_fts(tasks_fts, rowid, title, description, tags)
+		VALUES ('delete', old.id, old.title, old.description, old.tags);
+		INSERT INTO tasks_fts(rowid, title, description, tags)
+		VALUES (new.id, new.title, new.description, new.tags);
+	END;
+	`
+	if _, err := db.conn.Exec(triggers); err != nil {
+		return fmt.Errorf("failed to create search triggers: %w", err)
+	}
+
+	if synced == 0 {
+		if _, err := db.conn.Exec("INSERT INTO tasks_fts(tasks_fts) VALUES ('rebuild')"); err != nil {
+			return fmt.Errorf("failed to build search index: %w", err)
+		}
+	}
+
+	db.fts = true
+	return nil
+}
+
+// dropSearchTriggers removes the index triggers left by an FTS5 build, which
+// would make every task write fail without the module
+func (db *DB) dropSearchTriggers() error {
+	_, err := db.conn.Exec(`
+		DROP TRIGGER IF EXISTS tasks_fts_insert;
+		DROP TRIGGER IF EXISTS tasks_fts_delete;
+		DROP TRIGGER IF EXISTS tasks_fts_update;
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to drop search triggers: %w", err)
+	}
+	return nil
+}
+
+// HasFullTextSearch reports whether searches use the FTS5 index
+func (db *DB) HasFullTextSearch() bool {
+	return db.fts
+}
+
+// SearchTasks returns tasks whose title, description or tags contain every
+// word of query, best matches first. Quoted text matches as a phrase and a
+// trailing * matches word prefixes, e.g. `"login page" auth*`.
+func (db *DB) SearchTasks(query string, limit int) ([]model.Task, error) {
+	terms := parseSearchTerms(query, false)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	if !db.fts {
+		return db.likeSearch(terms, limit)
+	}
+
+	// Title hits outrank tag hits, which outrank description hits
+	sqlQuery := "SELECT " + taskColumns + ` FROM tasks JOIN (
+		SELECT rowid AS id, bm25(tasks_fts, 10.0, 1.0, 5.0) AS rank
+		FROM tasks_fts WHERE tasks_fts MATCH ?
+	) r USING (id) ORDER BY r.rank`
+	args := []interface{}{ftsQuery(terms)}
+	if limit > 0 {
+		sqlQuery += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := db.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTasks(rows)
+}
+
+// likeSearch is SearchTasks without FTS5, most recently updated first. LIKE
+// narrows the tasks down to those containing every term; matchWords then
+// keeps those matching on word boundaries as the index does.
+func (db *DB) likeSearch(terms []searchTerm, limit int) ([]model.Task, error) {
+	where, args := likeConditions(terms)
+	rows, err := db.conn.Query("SELECT "+taskColumns+" FROM tasks WHERE "+where+" ORDER BY updated_at DESC", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	matched := tasks[:0]
+	for _, task := range tasks {
+		if matchWords(terms, task.Title, task.Description, strings.Join(task.Tags, ",")) {
+			matched = append(matched, task)
+		}
+	}
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+// SearchTaskIDs returns the IDs of tasks matching query. With prefix set,
+// every unquoted word matches as a prefix, suiting search as you type.
+func (db *DB) SearchTaskIDs(query string, prefix bool) (map[int64]bool, error) {
+	ids := make(map[int64]bool)
+	terms := parseSearchTerms(query, prefix)
+	if len(terms) == 0 {
+		return ids, nil
+	}
+
+	if !db.fts {
+		return db.likeSearchIDs(terms)
+	}
+
+	rows, err := db.conn.Query("SELECT rowid FROM tasks_fts WHERE tasks_fts MATCH ?", ftsQuery(terms))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		ids[id] = true
+	}
+	return ids, rows.Err()
+}
+
+// likeSearchIDs is SearchTaskIDs without FTS5, see likeSearch
+func (db *DB) likeSearchIDs(terms []searchTerm) (map[int64]bool, error) {
+	where, args := likeConditions(terms)
+	rows, err := db.conn.Query("SELECT id, title, description, tags FROM tasks WHERE "+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		var title, description, tags string
+		if err := rows.Scan(&id, &title, &description, &tags); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if matchWords(terms, title, description, tags) {
+			ids[id] = true
+		}
+	}
+	return ids, rows.Err()
+}
+
+// parseSearchTerms splits query into words and quoted phrases. Words ending
+// in * are prefixes; with allPrefix every unquoted word is.
+func parseSearchTerms(query string, allPrefix bool) []searchTerm {
+	var terms []searchTerm
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		if rest[0] == '"' {
+			end := strings.IndexByte(rest[1:], '"')
+			var phrase string
+			if end < 0 {
+				phrase, rest = rest[1:], ""
+			} else {
+				phrase, rest = rest[1:end+1], rest[end+2:]
+			}
+			if phrase = strings.TrimSpace(phrase); phrase != "" {
+				terms = append(terms, searchTerm{text: phrase})
+			}
+		} else {
+			end := strings.IndexAny(rest, " \t\"")
+			var word string
+			if end < 0 {
+				word, rest = rest, ""
+			} else {
+				word, rest = rest[:end], rest[end:]
+			}
+			prefix := allPrefix || strings.HasSuffix(word, "*")
+			if word = strings.TrimRight(word, "*"); word != "" {
+				terms = append(terms, searchTerm{text: word, prefix: prefix})
+			}
+		}
+		rest = strings.TrimSpace(rest)
+	}
+	return terms
+}
+
+// ftsQuery builds an FTS5 MATCH expression requiring every term. Terms are
+// quoted so user input never parses as FTS5 syntax.
+func ftsQuery(terms []searchTerm) string {
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		parts[i] = `"` + strings.ReplaceAll(t.text, `"`, `""`) + `"`
+		if t.prefix {
+			parts[i] += "*"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// likeConditions builds a WHERE clause requiring every word of the terms as
+// a substring of title, description or tags, for SQLite builds without
+// FTS5. It lets through more than the index would; see matchWords.
+func likeConditions(terms []searchTerm) (string, []interface{}) {
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	conds := []string{"1"}
+	var args []interface{}
+	for _, t := range terms {
+		for _, word := range splitWords(t.text) {
+			conds = append(conds, `(title LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\' OR tags LIKE ? ESCAPE '\')`)
+			pattern := "%" + escaper.Replace(word) + "%"
+			args = append(args, pattern, pattern, pattern)
+		}
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// matchWords reports whether every term matches in one of fields the way
+// the FTS5 index matches: words are runs of letters and digits compared
+// without case, a phrase matches consecutive words and a prefix term
+// matches the start of its last word
+func matchWords(terms []searchTerm, fields ...string) bool {
+	fieldWords := make([][]string, len(fields))
+	for i, f := range fields {
+		fieldWords[i] = splitWords(f)
+	}
+	for _, t := range terms {
+		want := splitWords(t.text)
+		if len(want) == 0 {
+			continue
+		}
+		found := false
+		for _, words := range fieldWords {
+			if containsWords(words, want, t.prefix) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// splitWords splits s into lowercase words as the FTS5 tokenizer does
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsWords reports whether want occurs as consecutive words of words,
+// the last one as a prefix when prefix is set
+func containsWords(words, want []string, prefix bool) bool {
+	last := len(want) - 1
+	for start := 0; start+len(want) <= len(words); start++ {
+		matched := true
+		for i, w := range want {
+			word := words[start+i]
+			if word != w && !(prefix && i == last && strings.HasPrefix(word, w)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// sprintDateFormat is how sprint start and end dates are stored
+const sprintDateFormat = "2006-01-02"
+
+// CreateSprint creates a new sprint. Names that sprint references read
+// otherwise (current, backlog, none and numbers, taken as IDs) are refused,
+// as the sprint could not be named by them.
+func (db *DB) CreateSprint(name string, start, end time.Time) (*model.Sprint, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("sprint name is required")
+	}
+	switch strings.ToLower(name) {
+	case "current", "backlog", "none":
+		return nil, fmt.Errorf("sprint name %q is reserved", name)
+	}
+	if _, err := strconv.ParseInt(name, 10, 64); err == nil {
+		return nil, fmt.Errorf("sprint name must not be a number, which is read as a sprint ID")
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("sprint end must not be before its start")
+	}
+
+	result, err := db.conn.Exec(
+		"INSERT INTO sprints (name, start_date, end_date) VALUES (?, ?, ?)",
+		name, start.Format(sprintDateFormat), end.Format(sprintDateFormat),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sprint: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return &model.Sprint{
+		ID:    id,
+		Name:  name,
+		Start: dateOnly(start),
+		End:   dateOnly(end),
+	}, nil
+}
+
+// GetSprints retrieves all sprints ordered by start date
+func (db *DB) GetSprints() ([]model.Sprint, error) {
+	rows, err := db.conn.Query(
+		"SELECT id, name, start_date, end_date, closed_at FROM sprints ORDER BY start_date, id",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sprints: %w", err)
+	}
+	defer rows.Close()
+
+	var sprints []model.Sprint
+	for rows.Next() {
+		var s model.Sprint
+		var startStr, endStr string
+		var closedAt sql.NullTime
+		if err := rows.Scan(&s.ID, &s.Name, &startStr, &endStr, &closedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan sprint: %w", err)
+		}
+		if s.Start, err = time.ParseInLocation(sprintDateFormat, startStr, time.Local); err != nil {
+			return nil, fmt.Errorf("invalid start date for sprint %q: %w", s.Name, err)
+		}
+		if s.End, err = time.ParseInLocation(sprintDateFormat, endStr, time.Local); err != nil {
+			return nil, fmt.Errorf("invalid end date for sprint %q: %w", s.Name, err)
+		}
+		if closedAt.Valid {
+			t := closedAt.Time
+			s.ClosedAt = &t
+		}
+		sprints = append(sprints, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sprints: %w", err)
+	}
+
+	return sprints, nil
+}
+
+// ResolveSprint finds a sprint by ID, name, or the keyword "current"
+func (db *DB) ResolveSprint(ref string) (*model.Sprint, error) {
+	sprints, err := db.GetSprints()
+	if err != nil {
+		return nil, err
+	}
+
+	ref = strings.TrimSpace(ref)
+	if strings.EqualFold(ref, "current") {
+		if s := CurrentSprint(sprints, time.Now()); s != nil {
+			return s, nil
+		}
+		return nil, fmt.Errorf("no open sprint covers today")
+	}
+
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		for i := range sprints {
+			if sprints[i].ID == id {
+				return &sprints[i], nil
+			}
+		}
+	}
+	for i := range sprints {
+		if strings.EqualFold(sprints[i].Name, ref) {
+			return &sprints[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("sprint %q not found", ref)
+}
+
+// CurrentSprint returns the open sprint covering now, or nil if there is none
+func CurrentSprint(sprints []model.Sprint, now time.Time) *model.Sprint {
+	for i := range sprints {
+		if !sprints[i].Closed() && sprints[i].Contains(now) {
+			return &sprints[i]
+		}
+	}
+	return nil
+}
+
+// NextSprint returns the earliest open sprint starting after the given one, or nil
+func NextSprint(sprints []model.Sprint, after model.Sprint) *model.Sprint {
+	var next *model.Sprint
+	for i := range sprints {
+		s := &sprints[i]
+		if s.ID == after.ID || s.Closed() || !s.Start.After(after.Start) {
+			continue
+		}
+		if next == nil || s.Start.Before(next.Start) {
+			next = s
+		}
+	}
+	return next
+}
+
+// AssignTaskSprint assigns a task to a sprint (nil moves it back to the backlog)
+func (db *DB) AssignTaskSprint(taskID int64, sprintID *int64) error {
+	var sprintValue interface{}
+	if sprintID != nil {
+		sprintValue = *sprintID
+	}
+
+	result, err := db.conn.Exec(
+		"UPDATE tasks SET sprint_id = ?, updated_at = ? WHERE id = ?",
+		sprintValue, time.Now(), taskID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to assign task to sprint: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("task not found")
+	}
+
+	return nil
+}
+
+// CloseSprint closes a sprint, moving its unfinished tasks to the next sprint
+// and recording each of them as carried over. It returns the carried task IDs.
+func (db *DB) CloseSprint(sprintID, nextSprintID int64) ([]int64, error) {
+	if sprintID == nextSprintID {
+		return nil, fmt.Errorf("cannot carry tasks over into the sprint being closed")
+	}
+	now := time.Now()
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("UPDATE sprints SET closed_at = ? WHERE id = ? AND closed_at IS NULL", now, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close sprint: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	} else if rows == 0 {
+		return nil, fmt.Errorf("sprint not found or already closed")
+	}
+
+	var nextClosedAt sql.NullTime
+	err = tx.QueryRow("SELECT closed_at FROM sprints WHERE id = ?", nextSprintID).Scan(&nextClosedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("next sprint not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read next sprint: %w", err)
+	}
+	if nextClosedAt.Valid {
+		return nil, fmt.Errorf("cannot carry tasks over into a closed sprint")
+	}
+
+	rows, err := tx.Query("SELECT id FROM tasks WHERE sprint_id = ? AND status != ?", sprintID, model.StatusDone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unfinished tasks: %w", err)
+	}
+	var carried []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan task id: %w", err)
+		}
+		carried = append(carried, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read unfinished tasks: %w", err)
+	}
+
+	for _, id := range carried {
+		if _, err := tx.Exec("UPDATE tasks SET sprint_id = ?, updated_at = ? WHERE id = ?", nextSprintID, now, id); err != nil {
+			return nil, fmt.Errorf("failed to carry over task %d: %w", id, err)
+		}
+		_, err := tx.Exec(
+			"INSERT INTO sprint_carryovers (task_id, from_sprint_id, to_sprint_id, carried_at) VALUES (?, ?, ?, ?)",
+			id, sprintID, nextSprintID, now,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record carryover of task %d: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return carried, nil
+}
+
+// GetCarryovers retrieves the tasks carried out of a sprint when it was closed
+func (db *DB) GetCarryovers(fromSprintID int64) ([]model.Carryover, error) {
+	rows, err := db.conn.Query(
+		"SELECT task_id, from_sprint_id, to_sprint_id, carried_at FROM sprint_carryovers WHERE from_sprint_id = ? ORDER BY task_id",
+		fromSprintID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query carryovers: %w", err)
+	}
+	defer rows.Close()
+
+	var carryovers []model.Carryover
+	for rows.Next() {
+		var c model.Carryover
+		if err := rows.Scan(&c.TaskID, &c.FromSprintID, &c.ToSprintID, &c.CarriedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan carryover: %w", err)
+		}
+		carryovers = append(carryovers, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read carryovers: %w", err)
+	}
+
+	return carryovers, nil
+}
+
+// dateOnly truncates t to local midnight
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -12,6 +12,7 @@ import (
 
 type DB struct {
 	conn *sql.DB
+	fts  bool // tasks_fts full-text index is available
 }
 
 // New creates a new database connection and initializes tables
@@ -48,6 +49,60 @@ func (db *DB) initTables() error {
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
+
+	CREATE TABLE IF NOT EXISTS time_entries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		started_at DATETIME NOT NULL,
+		ended_at DATETIME DEFAULT NULL,
+		note TEXT DEFAULT ''
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_time_entries_task ON time_entries(task_id);
+
+	CREATE TABLE IF NOT EXISTS pomodoros (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		started_at DATETIME NOT NULL,
+		completed_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_pomodoros_task ON pomodoros(task_id);
+
+	CREATE TABLE IF NOT EXISTS status_transitions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		from_status TEXT NOT NULL DEFAULT '',
+		to_status TEXT NOT NULL,
+		changed_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_status_transitions_task ON status_transitions(task_id);
+
+	CREATE TABLE IF NOT EXISTS sprints (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		start_date TEXT NOT NULL,
+		end_date TEXT NOT NULL,
+		closed_at DATETIME DEFAULT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS sprint_carryovers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		from_sprint_id INTEGER NOT NULL,
+		to_sprint_id INTEGER NOT NULL,
+		carried_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS saved_views (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+		query TEXT NOT NULL DEFAULT '',
+		sort TEXT NOT NULL DEFAULT '',
+		columns TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL
+	);
 	`
 
 	_, err := db.conn.Exec(schema)
@@ -76,15 +131,67 @@ func (db *DB) initTables() error {
 	`)
 	// Ignore error if column already exists
 
-	return nil
+	// Migrate existing tables to add due_all_day column if it doesn't exist
+	_, err = db.conn.Exec(`
+		ALTER TABLE tasks ADD COLUMN due_all_day INTEGER DEFAULT NULL;
+	`)
+	// Ignore error if column already exists
+
+	// Migrate existing tables to add estimate column if it doesn't exist
+	_, err = db.conn.Exec(`
+		ALTER TABLE tasks ADD COLUMN estimate REAL DEFAULT NULL;
+	`)
+	// Ignore error if column already exists
+
+	// Migrate existing tables to add sprint_id column if it doesn't exist
+	_, err = db.conn.Exec(`
+		ALTER TABLE tasks ADD COLUMN sprint_id INTEGER DEFAULT NULL;
+	`)
+	// Ignore error if column already exists
+
+	// Migrate existing tables to add status_changed_at column if it doesn't exist
+	_, err = db.conn.Exec(`
+		ALTER TABLE tasks ADD COLUMN status_changed_at DATETIME DEFAULT NULL;
+	`)
+	// Ignore error if column already exists
+
+	// Migrate existing tables to add archived_at column if it doesn't exist
+	_, err = db.conn.Exec(`
+		ALTER TABLE tasks ADD COLUMN archived_at DATETIME DEFAULT NULL;
+	`)
+	// Ignore error if column already exists
+
+	// Seed status history for tasks created before it was recorded
+	if err := db.backfillTransitions(); err != nil {
+		return err
+	}
+	if err := db.backfillStatusChangedAt(); err != nil {
+		return err
+	}
+	if err := db.migrateDueDays(); err != nil {
+		return err
+	}
+
+	return db.initSearch()
 }
 
-// CreateTask creates a new task
-func (db *DB) CreateTask(title string, status model.TaskStatus) (*model.Task, error) {
+// CreateTask creates a new task, in a sprint unless sprintID is nil
+func (db *DB) CreateTask(title string, status model.TaskStatus, sprintID *int64) (*model.Task, error) {
 	now := time.Now()
-	result, err := db.conn.Exec(
-		"INSERT INTO tasks (title, description, tags, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)",
-		title, "", "", status, now, now,
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var sprintValue interface{}
+	if sprintID != nil {
+		sprintValue = *sprintID
+	}
+	result, err := tx.Exec(
+		"INSERT INTO tasks (title, description, tags, status, sprint_id, status_changed_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		title, "", "", status, sprintValue, now, now, now,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create task: %w", err)
@@ -95,48 +202,78 @@ func (db *DB) CreateTask(title string, status model.TaskStatus) (*model.Task, er
 		return nil, fmt.Errorf("failed to get last insert id: %w", err)
 	}
 
+	if err := recordTransition(tx, id, "", status, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return &model.Task{
-		ID:          id,
-		Title:       title,
-		Description: "",
-		Tags:        []string{},
-		Status:      status,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:              id,
+		Title:           title,
+		Description:     "",
+		Tags:            []string{},
+		Status:          status,
+		SprintID:        sprintID,
+		StatusChangedAt: now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
 	}, nil
 }
 
 // GetAllTasks retrieves all tasks
 func (db *DB) GetAllTasks() ([]model.Task, error) {
 	rows, err := db.conn.Query(
-		"SELECT id, title, description, tags, due, status, created_at, updated_at FROM tasks ORDER BY created_at DESC",
+		"SELECT " + taskColumns + " FROM tasks ORDER BY created_at DESC",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tasks: %w", err)
 	}
 	defer rows.Close()
 
-	var tasks []model.Task
-	for rows.Next() {
-		var task model.Task
-		var tagsStr string
-		var dueStr sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &tagsStr, &dueStr, &task.Status, &task.CreatedAt, &task.UpdatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
-		}
-		task.Tags = parseTags(tagsStr)
-		task.Due = parseDue(dueStr)
-		tasks = append(tasks, task)
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.attachTimeTracking(tasks); err != nil {
+		return nil, err
+	}
+	if err := db.attachPomodoros(tasks); err != nil {
+		return nil, err
 	}
-
 	return tasks, nil
 }
 
+// GetTask retrieves a single task by ID
+func (db *DB) GetTask(id int64) (*model.Task, error) {
+	rows, err := db.conn.Query("SELECT "+taskColumns+" FROM tasks WHERE id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task: %w", err)
+	}
+	defer rows.Close()
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("task not found")
+	}
+	if err := db.attachTimeTracking(tasks); err != nil {
+		return nil, err
+	}
+	if err := db.attachPomodoros(tasks); err != nil {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
+
 // GetTasksByStatus retrieves tasks by status
 func (db *DB) GetTasksByStatus(status model.TaskStatus) ([]model.Task, error) {
 	rows, err := db.conn.Query(
-		"SELECT id, title, description, tags, due, status, created_at, updated_at FROM tasks WHERE status = ? ORDER BY created_at DESC",
+		"SELECT "+taskColumns+" FROM tasks WHERE status = ? ORDER BY created_at DESC",
 		status,
 	)
 	if err != nil {
@@ -144,40 +281,145 @@ func (db *DB) GetTasksByStatus(status model.TaskStatus) ([]model.Task, error) {
 	}
 	defer rows.Close()
 
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.attachTimeTracking(tasks); err != nil {
+		return nil, err
+	}
+	if err := db.attachPomodoros(tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// taskColumns is the column list read by scanTasks
+const taskColumns = "id, title, description, tags, due, due_all_day, estimate, status, sprint_id, status_changed_at, archived_at, created_at, updated_at"
+
+// scanTasks reads task rows selected with taskColumns
+func scanTasks(rows *sql.Rows) ([]model.Task, error) {
 	var tasks []model.Task
 	for rows.Next() {
 		var task model.Task
 		var tagsStr string
-		var dueStr sql.NullString
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &tagsStr, &dueStr, &task.Status, &task.CreatedAt, &task.UpdatedAt)
+		var due sql.NullTime
+		var dueAllDay sql.NullBool
+		var estimate sql.NullFloat64
+		var sprintID sql.NullInt64
+		var statusChangedAt, archivedAt sql.NullTime
+		err := rows.Scan(&task.ID, &task.Title, &task.Description, &tagsStr, &due, &dueAllDay, &estimate, &task.Status, &sprintID, &statusChangedAt, &archivedAt, &task.CreatedAt, &task.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan task: %w", err)
 		}
 		task.Tags = parseTags(tagsStr)
-		task.Due = parseDue(dueStr)
+		task.Due = parseDue(due, dueAllDay.Bool)
+		if estimate.Valid {
+			v := estimate.Float64
+			task.Estimate = &v
+		}
+		if sprintID.Valid {
+			id := sprintID.Int64
+			task.SprintID = &id
+		}
+		task.StatusChangedAt = task.CreatedAt
+		if statusChangedAt.Valid {
+			task.StatusChangedAt = statusChangedAt.Time
+		}
+		if archivedAt.Valid {
+			t := archivedAt.Time
+			task.ArchivedAt = &t
+		}
 		tasks = append(tasks, task)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tasks: %w", err)
+	}
 
 	return tasks, nil
 }
 
 // UpdateTask updates a task
 func (db *DB) UpdateTask(id int64, title string, status model.TaskStatus) error {
-	result, err := db.conn.Exec(
-		"UPDATE tasks SET title = ?, status = ?, updated_at = ? WHERE id = ?",
-		title, status, time.Now(), id,
-	)
+	now := time.Now()
+
+	tx, err := db.conn.Begin()
 	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := setStatus(tx, id, status, now); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE tasks SET title = ? WHERE id = ?", title, id); err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
 
-	rows, err := result.RowsAffected()
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyTaskEdit saves the fields of edit that differ from original in one
+// transaction. Fields left as they were are not written, so changes made to
+// them elsewhere while the task was being edited are kept.
+func (db *DB) ApplyTaskEdit(id int64, original, edit model.TaskEdit) error {
+	var sets []string
+	var args []interface{}
+	if edit.Title != original.Title {
+		sets = append(sets, "title = ?")
+		args = append(args, edit.Title)
+	}
+	if tags := tagsToString(edit.Tags); tags != tagsToString(original.Tags) {
+		sets = append(sets, "tags = ?")
+		args = append(args, tags)
+	}
+	if (edit.Due == nil) != (original.Due == nil) || edit.Due != nil && !edit.Due.Equal(*original.Due) {
+		value, allDay := dueValue(edit.Due)
+		sets = append(sets, "due = ?", "due_all_day = ?")
+		args = append(args, value, allDay)
+	}
+	if edit.Description != original.Description {
+		sets = append(sets, "description = ?")
+		args = append(args, edit.Description)
+	}
+	statusChanged := edit.Status != original.Status
+	if len(sets) == 0 && !statusChanged {
+		return nil
+	}
+
+	now := time.Now()
+
+	tx, err := db.conn.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	if rows == 0 {
-		return fmt.Errorf("task not found")
+	if statusChanged {
+		if err := setStatus(tx, id, edit.Status, now); err != nil {
+			return err
+		}
+	}
+
+	if len(sets) > 0 {
+		sets = append(sets, "updated_at = ?")
+		args = append(args, now, id)
+		result, err := tx.Exec("UPDATE tasks SET "+strings.Join(sets, ", ")+" WHERE id = ?", args...)
+		if err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+		if err := expectRow(result, id, "task not found"); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -185,21 +427,18 @@ func (db *DB) UpdateTask(id int64, title string, status model.TaskStatus) error
 
 // UpdateTaskStatus updates only the status of a task
 func (db *DB) UpdateTaskStatus(id int64, status model.TaskStatus) error {
-	result, err := db.conn.Exec(
-		"UPDATE tasks SET status = ?, updated_at = ? WHERE id = ?",
-		status, time.Now(), id,
-	)
+	tx, err := db.conn.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to update task status: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+	if err := setStatus(tx, id, status, time.Now()); err != nil {
+		return err
 	}
 
-	if rows == 0 {
-		return fmt.Errorf("task not found")
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -227,9 +466,44 @@ func (db *DB) UpdateTaskDescription(id int64, description string) error {
 	return nil
 }
 
-// DeleteTask deletes a task
+// DeleteTask deletes a task along with its time entries and pomodoros; its
+// status history ends with the deletion
 func (db *DB) DeleteTask(id int64) error {
-	result, err := db.conn.Exec("DELETE FROM tasks WHERE id = ?", id)
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := deleteTask(tx, id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// deleteTask deletes a task, its time entries and pomodoros inside tx. Its
+// status history is kept for reports, closed by a transition out of its
+// last status so charts stop counting it from then on.
+func deleteTask(tx *sql.Tx, id int64) error {
+	if _, err := tx.Exec("DELETE FROM time_entries WHERE task_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete time entries: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM pomodoros WHERE task_id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete pomodoros: %w", err)
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO status_transitions (task_id, from_status, to_status, changed_at) SELECT id, status, '', ? FROM tasks WHERE id = ?",
+		time.Now(), id,
+	); err != nil {
+		return fmt.Errorf("failed to record status change: %w", err)
+	}
+
+	result, err := tx.Exec("DELETE FROM tasks WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
@@ -299,37 +573,109 @@ func tagsToString(tags []string) string {
 	return strings.Join(cleaned, ",")
 }
 
-// parseDue converts nullable string to *time.Time
-func parseDue(dueStr sql.NullString) *time.Time {
-	if !dueStr.Valid || dueStr.String == "" {
+// dueValue converts a due date to its stored form and all-day flag, NULL
+// when unset. A due time is stored with its UTC offset so it reads back as
+// the same instant; an all-day date is stored as YYYY-MM-DD so it reads
+// back as the same day in any timezone.
+func dueValue(due *model.Due) (value, allDay interface{}) {
+	if due == nil {
+		return nil, nil
+	}
+	if due.AllDay {
+		return due.Time.Format("2006-01-02"), true
+	}
+	return due.Time, false
+}
+
+// parseDue converts a stored due date to local time, nil when unset. The
+// driver reads an all-day YYYY-MM-DD as midnight UTC of that day.
+func parseDue(due sql.NullTime, allDay bool) *model.Due {
+	if !due.Valid || due.Time.IsZero() {
 		return nil
 	}
-	// Try parsing with different formats
-	formats := []string{
-		"2006-01-02 15:04:05",
-		"2006-01-02T15:04:05Z",
-		"2006-01-02",
+	if allDay {
+		t := due.Time.UTC()
+		date := model.Date(t.Year(), t.Month(), t.Day())
+		return &date
+	}
+	return &model.Due{Time: due.Time.Local()}
+}
+
+// migrateDueDays sets the all-day flag of due dates stored by earlier
+// versions. Those stored a date as midnight, either without a UTC offset in
+// the user's timezone or with the offset it was set in; a midnight due date
+// becomes an all-day date on that day, anything else a due time.
+func (db *DB) migrateDueDays() error {
+	rows, err := db.conn.Query("SELECT id, CAST(due AS TEXT) FROM tasks WHERE due IS NOT NULL AND due_all_day IS NULL")
+	if err != nil {
+		return fmt.Errorf("failed to query due dates: %w", err)
 	}
-	for _, format := range formats {
-		if t, err := time.Parse(format, dueStr.String); err == nil {
-			return &t
+	dues := make(map[int64]model.Due)
+	for rows.Next() {
+		var id int64
+		var value string
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan due date: %w", err)
+		}
+		if due, ok := parseStoredDue(value); ok {
+			dues[id] = due
 		}
 	}
-	return nil
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read due dates: %w", err)
+	}
+	if len(dues) == 0 {
+		return nil
+	}
+
+	return db.inTransaction(func(tx *sql.Tx) error {
+		for id, due := range dues {
+			value, allDay := dueValue(&due)
+			if _, err := tx.Exec("UPDATE tasks SET due = ?, due_all_day = ? WHERE id = ?", value, allDay, id); err != nil {
+				return fmt.Errorf("failed to migrate due date: %w", err)
+			}
+		}
+		return nil
+	})
 }
 
-// UpdateTaskDue updates a task's due date
-func (db *DB) UpdateTaskDue(id int64, due *time.Time) error {
-	var dueValue interface{}
-	if due != nil {
-		dueValue = due.Format("2006-01-02 15:04:05")
-	} else {
-		dueValue = nil
+// parseStoredDue reads a due date stored by an earlier version, with or
+// without a UTC offset. Midnight in its own timezone is an all-day date.
+func parseStoredDue(value string) (model.Due, bool) {
+	value = strings.TrimSuffix(value, "Z")
+	var t time.Time
+	parsed := false
+	for _, format := range []string{"2006-01-02 15:04:05.999999999-07:00", "2006-01-02T15:04:05.999999999-07:00"} {
+		if v, err := time.Parse(format, value); err == nil {
+			t, parsed = v, true
+			break
+		}
 	}
+	if !parsed {
+		for _, format := range []string{"2006-01-02 15:04:05.999999999", "2006-01-02T15:04:05.999999999", "2006-01-02 15:04", "2006-01-02"} {
+			if v, err := time.ParseInLocation(format, value, time.Local); err == nil {
+				t, parsed = v, true
+				break
+			}
+		}
+	}
+	if !parsed {
+		return model.Due{}, false
+	}
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return model.Date(t.Year(), t.Month(), t.Day()), true
+	}
+	return model.Due{Time: t.Local()}, true
+}
 
+// UpdateTaskDue updates a task's due date
+func (db *DB) UpdateTaskDue(id int64, due *model.Due) error {
+	value, allDay := dueValue(due)
 	result, err := db.conn.Exec(
-		"UPDATE tasks SET due = ?, updated_at = ? WHERE id = ?",
-		dueValue, time.Now(), id,
+		"UPDATE tasks SET due = ?, due_all_day = ?, updated_at = ? WHERE id = ?",
+		value, allDay, time.Now(), id,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update task due: %w", err)
@@ -346,3 +692,30 @@ func (db *DB) UpdateTaskDue(id int64, due *time.Time) error {
 
 	return nil
 }
+
+// UpdateTaskEstimate updates a task's estimate (nil clears it)
+func (db *DB) UpdateTaskEstimate(id int64, estimate *float64) error {
+	var estimateValue interface{}
+	if estimate != nil {
+		estimateValue = *estimate
+	}
+
+	result, err := db.conn.Exec(
+		"UPDATE tasks SET estimate = ?, updated_at = ? WHERE id = ?",
+		estimateValue, time.Now(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update task estimate: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("task not found")
+	}
+
+	return nil
+}
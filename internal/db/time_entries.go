@@ -0,0 +1,214 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// StartTimer starts a timer on a task, stopping any other running timer first
+func (db *DB) StartTimer(taskID int64) (*model.TimeEntry, error) {
+	now := time.Now()
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM tasks WHERE id = ?", taskID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to look up task: %w", err)
+	}
+	if exists == 0 {
+		return nil, fmt.Errorf("task not found")
+	}
+
+	if _, err := tx.Exec("UPDATE time_entries SET ended_at = ? WHERE ended_at IS NULL", now); err != nil {
+		return nil, fmt.Errorf("failed to stop running timers: %w", err)
+	}
+
+	result, err := tx.Exec(
+		"INSERT INTO time_entries (task_id, started_at, note) VALUES (?, ?, ?)",
+		taskID, now, "",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start timer: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &model.TimeEntry{ID: id, TaskID: taskID, StartedAt: now}, nil
+}
+
+// StopTimer stops the running timer of a task and records an optional note
+func (db *DB) StopTimer(taskID int64, note string) (*model.TimeEntry, error) {
+	entry, err := db.GetRunningTimer()
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.TaskID != taskID {
+		return nil, fmt.Errorf("no running timer for task %d", taskID)
+	}
+
+	now := time.Now()
+	_, err = db.conn.Exec(
+		"UPDATE time_entries SET ended_at = ?, note = ? WHERE id = ?",
+		now, note, entry.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	entry.EndedAt = &now
+	entry.Note = note
+	return entry, nil
+}
+
+// GetRunningTimer returns the currently running time entry, or nil if none is running
+func (db *DB) GetRunningTimer() (*model.TimeEntry, error) {
+	rows, err := db.conn.Query(
+		"SELECT id, task_id, started_at, ended_at, note FROM time_entries WHERE ended_at IS NULL ORDER BY started_at DESC LIMIT 1",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query running timer: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanTimeEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+// GetTimeEntries retrieves all time entries started at or after since.
+// A zero since returns every entry.
+func (db *DB) GetTimeEntries(since time.Time) ([]model.TimeEntry, error) {
+	rows, err := db.conn.Query(
+		"SELECT id, task_id, started_at, ended_at, note FROM time_entries ORDER BY started_at",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanTimeEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter in Go: stored timestamps carry their own UTC offset, so string
+	// comparison in SQL is unreliable across DST changes.
+	if since.IsZero() {
+		return entries, nil
+	}
+	filtered := entries[:0]
+	for _, e := range entries {
+		if !e.StartedAt.Before(since) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered, nil
+}
+
+// GetTaskTimeEntries retrieves a task's time entries, oldest first
+func (db *DB) GetTaskTimeEntries(taskID int64) ([]model.TimeEntry, error) {
+	rows, err := db.conn.Query(
+		"SELECT id, task_id, started_at, ended_at, note FROM time_entries WHERE task_id = ? ORDER BY started_at",
+		taskID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time entries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTimeEntries(rows)
+}
+
+// scanTimeEntries reads time entry rows
+func scanTimeEntries(rows *sql.Rows) ([]model.TimeEntry, error) {
+	var entries []model.TimeEntry
+	for rows.Next() {
+		var e model.TimeEntry
+		var endedAt sql.NullTime
+		if err := rows.Scan(&e.ID, &e.TaskID, &e.StartedAt, &endedAt, &e.Note); err != nil {
+			return nil, fmt.Errorf("failed to scan time entry: %w", err)
+		}
+		if endedAt.Valid {
+			t := endedAt.Time
+			e.EndedAt = &t
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read time entries: %w", err)
+	}
+	return entries, nil
+}
+
+// attachTimeTracking fills in the tracked time and running timer of each task.
+// Stopped entries are summed in SQL, which parses the offsets stored with
+// the timestamps; only the running timer is read.
+func (db *DB) attachTimeTracking(tasks []model.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(tasks))
+	for i := range tasks {
+		index[tasks[i].ID] = i
+	}
+
+	query := `SELECT task_id, SUM(MAX(0, julianday(ended_at) - julianday(started_at))) * 86400
+		FROM time_entries WHERE ended_at IS NOT NULL`
+	var args []interface{}
+	if len(tasks) == 1 {
+		query += " AND task_id = ?"
+		args = append(args, tasks[0].ID)
+	}
+	rows, err := db.conn.Query(query+" GROUP BY task_id", args...)
+	if err != nil {
+		return fmt.Errorf("failed to sum tracked time: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int64
+		var seconds sql.NullFloat64
+		if err := rows.Scan(&taskID, &seconds); err != nil {
+			return fmt.Errorf("failed to scan tracked time: %w", err)
+		}
+		if i, ok := index[taskID]; ok {
+			tasks[i].Tracked = time.Duration(math.Round(seconds.Float64*1000)) * time.Millisecond
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read tracked time: %w", err)
+	}
+
+	running, err := db.GetRunningTimer()
+	if err != nil {
+		return err
+	}
+	if running != nil {
+		if i, ok := index[running.TaskID]; ok {
+			start := running.StartedAt
+			tasks[i].TimerStart = &start
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// SaveView stores a named view, replacing the query and options of an
+// existing view with the same name
+func (db *DB) SaveView(view model.SavedView) (*model.SavedView, error) {
+	view.Name = strings.TrimSpace(view.Name)
+	if view.Name == "" {
+		return nil, fmt.Errorf("view name is required")
+	}
+
+	columns := make([]string, len(view.Columns))
+	for i, s := range view.Columns {
+		columns[i] = string(s)
+	}
+
+	_, err := db.conn.Exec(`
+		INSERT INTO saved_views (name, query, sort, columns, created_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET query = excluded.query, sort = excluded.sort, columns = excluded.columns`,
+		view.Name, view.Query, string(view.Sort), strings.Join(columns, ","), time.Now(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save view: %w", err)
+	}
+	return db.GetView(view.Name)
+}
+
+// GetViews retrieves all saved views in creation order
+func (db *DB) GetViews() ([]model.SavedView, error) {
+	rows, err := db.conn.Query("SELECT id, name, query, sort, columns, created_at FROM saved_views ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query views: %w", err)
+	}
+	defer rows.Close()
+
+	var views []model.SavedView
+	for rows.Next() {
+		var v model.SavedView
+		var sort, columns string
+		if err := rows.Scan(&v.ID, &v.Name, &v.Query, &sort, &columns, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan view: %w", err)
+		}
+		v.Sort = model.ViewSort(sort)
+		for _, c := range strings.Split(columns, ",") {
+			if c != "" {
+				v.Columns = append(v.Columns, model.TaskStatus(c))
+			}
+		}
+		views = append(views, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read views: %w", err)
+	}
+	return views, nil
+}
+
+// GetView finds a saved view by name (case-insensitive)
+func (db *DB) GetView(name string) (*model.SavedView, error) {
+	views, err := db.GetViews()
+	if err != nil {
+		return nil, err
+	}
+	for i := range views {
+		if strings.EqualFold(views[i].Name, strings.TrimSpace(name)) {
+			return &views[i], nil
+		}
+	}
+	return nil, fmt.Errorf("view %q not found", name)
+}
+
+// DeleteView deletes a saved view by name
+func (db *DB) DeleteView(name string) error {
+	view, err := db.GetView(name)
+	if err != nil {
+		return err
+	}
+	if _, err := db.conn.Exec("DELETE FROM saved_views WHERE id = ?", view.ID); err != nil {
+		return fmt.Errorf("failed to delete view: %w", err)
+	}
+	return nil
+}
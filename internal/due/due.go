@@ -0,0 +1,422 @@
+// Package due parses due dates as people type them: dates, relative offsets
+// and phrases like "next monday" or "end of month", with an optional time of
+// day.
+package due
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// Examples lists inputs Parse accepts, for hints and error messages
+const Examples = "today, fri, next monday, +3d, in 2 weeks, end of month, 2026-11-01 14:00"
+
+// Input forms. clockPattern matches a time of day ending the input: "14:00",
+// "2pm", "2:30 pm" or "at 9".
+var (
+	clockPattern  = regexp.MustCompile(`^(.*?)(\s*\bat)?\s*\b(\d{1,2})(?::(\d{2}))?\s*([ap]m)?$`)
+	offsetPattern = regexp.MustCompile(`^([+-])\s*(\d+)\s*([a-z]*)$`)
+	inPattern     = regexp.MustCompile(`^in\s+(\d+|an?|one)\s*([a-z]+)$`)
+	dayPattern    = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+	yearPattern   = regexp.MustCompile(`^\d{4}$`)
+)
+
+// maxCount bounds the count of an offset, so "+99999999999d" is an error
+// rather than a date that overflows
+const maxCount = 99999
+
+var weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+var months = []string{"january", "february", "march", "april", "may", "june", "july",
+	"august", "september", "october", "november", "december"}
+
+// Parse resolves a due date typed by a user relative to now. Dates without
+// a time of day are all-day due dates; a time of day may follow any date
+// form and resolves in now's location. Empty input and "none" return nil,
+// which clears the due date.
+func Parse(input string, now time.Time) (*model.Due, error) {
+	s := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	switch s {
+	case "", "none", "clear":
+		return nil, nil
+	}
+
+	// Offsets in hours or minutes are exact instants
+	if t, ok, err := parseShortOffset(s, now); err != nil {
+		return nil, err
+	} else if ok {
+		return &model.Due{Time: t}, nil
+	}
+
+	datePart, hour, minute, hasClock, err := splitClock(s)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	day := today
+	if datePart != "" {
+		var ok bool
+		if day, ok, err = parseDate(datePart, today); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, fmt.Errorf("unrecognized due date %q, try %s", strings.TrimSpace(input), Examples)
+		}
+	} else if !hasClock {
+		return nil, fmt.Errorf("unrecognized due date %q, try %s", strings.TrimSpace(input), Examples)
+	}
+
+	if !hasClock {
+		date := model.Date(day.Year(), day.Month(), day.Day())
+		return &date, nil
+	}
+	return &model.Due{Time: time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())}, nil
+}
+
+// splitClock removes a trailing time of day from s. A bare number is only a
+// time after "at", so "nov 1" keeps its day.
+func splitClock(s string) (rest string, hour, minute int, ok bool, err error) {
+	match := clockPattern.FindStringSubmatch(s)
+	if match == nil {
+		return s, 0, 0, false, nil
+	}
+	rest, at, minutes, meridiem := strings.TrimSpace(match[1]), match[2], match[4], match[5]
+	if minutes == "" && meridiem == "" && at == "" {
+		return s, 0, 0, false, nil
+	}
+
+	clock := strings.TrimSpace(s[len(match[1]):])
+	hour, _ = strconv.Atoi(match[3])
+	if minutes != "" {
+		minute, _ = strconv.Atoi(minutes)
+	}
+	if meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return "", 0, 0, false, fmt.Errorf("invalid time %q", clock)
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return "", 0, 0, false, fmt.Errorf("invalid time %q", clock)
+	}
+	return rest, hour, minute, true, nil
+}
+
+// parseShortOffset parses "+2h", "+30min", "in 2 hours" and "in 45 minutes"
+func parseShortOffset(s string, now time.Time) (time.Time, bool, error) {
+	var count, unit string
+	if match := offsetPattern.FindStringSubmatch(s); match != nil && match[1] == "+" {
+		count, unit = match[2], match[3]
+	} else if match := inPattern.FindStringSubmatch(s); match != nil {
+		count, unit = match[1], match[2]
+	} else {
+		return time.Time{}, false, nil
+	}
+
+	var step time.Duration
+	switch unit {
+	case "h", "hr", "hrs", "hour", "hours":
+		step = time.Hour
+	case "min", "mins", "minute", "minutes":
+		step = time.Minute
+	default:
+		return time.Time{}, false, nil
+	}
+	n, err := countValue(count)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return now.Add(time.Duration(n) * step).Truncate(time.Minute), true, nil
+}
+
+// parseDate resolves the date part of the input relative to today. It
+// fails only for offsets with an invalid count; other input it does not
+// recognize returns false.
+func parseDate(s string, today time.Time) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, today.Location()); err == nil {
+		return t, true, nil
+	}
+
+	switch s {
+	case "today", "tod":
+		return today, true, nil
+	case "tomorrow", "tom", "tmr":
+		return today.AddDate(0, 0, 1), true, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true, nil
+	case "end of week", "eow":
+		return startOfWeek(today).AddDate(0, 0, 6), true, nil
+	case "end of month", "eom":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()), true, nil
+	case "end of year", "eoy":
+		return time.Date(today.Year(), time.December, 31, 0, 0, 0, 0, today.Location()), true, nil
+	case "next week":
+		return startOfWeek(today).AddDate(0, 0, 7), true, nil
+	case "next month":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), true, nil
+	case "next year":
+		return time.Date(today.Year()+1, time.January, 1, 0, 0, 0, 0, today.Location()), true, nil
+	}
+
+	if match := offsetPattern.FindStringSubmatch(s); match != nil {
+		n, err := countValue(match[2])
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		if match[1] == "-" {
+			n = -n
+		}
+		t, ok := addUnits(today, n, match[3])
+		return t, ok, nil
+	}
+	if match := inPattern.FindStringSubmatch(s); match != nil {
+		n, err := countValue(match[1])
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		t, ok := addUnits(today, n, match[2])
+		return t, ok, nil
+	}
+
+	words := strings.Fields(s)
+	if len(words) == 1 || len(words) == 2 && (words[0] == "this" || words[0] == "next") {
+		name := words[len(words)-1]
+		if weekday, ok := lookup(weekdays, name); ok {
+			return weekdayDate(today, time.Weekday(weekday), words[0]), true, nil
+		}
+	}
+	t, ok := parseMonthDay(words, today)
+	return t, ok, nil
+}
+
+// weekdayDate resolves a weekday: the next one after today, the one in this
+// week ("this fri") or the one in next week ("next fri"). Weeks start on
+// Monday.
+func weekdayDate(today time.Time, weekday time.Weekday, qualifier string) time.Time {
+	switch qualifier {
+	case "this":
+		return startOfWeek(today).AddDate(0, 0, (int(weekday)+6)%7)
+	case "next":
+		return startOfWeek(today).AddDate(0, 0, 7+(int(weekday)+6)%7)
+	}
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+// parseMonthDay parses "nov 1", "1 nov" and "november 1st 2027". Without a
+// year, a day already past this year means next year.
+func parseMonthDay(words []string, today time.Time) (time.Time, bool) {
+	year := 0
+	if len(words) == 3 && yearPattern.MatchString(words[2]) {
+		year, _ = strconv.Atoi(words[2])
+		words = words[:2]
+	}
+	if len(words) != 2 {
+		return time.Time{}, false
+	}
+
+	monthName, dayText := words[0], words[1]
+	if dayPattern.MatchString(monthName) {
+		monthName, dayText = dayText, monthName
+	}
+	month, ok := lookup(months, monthName)
+	match := dayPattern.FindStringSubmatch(dayText)
+	if !ok || match == nil {
+		return time.Time{}, false
+	}
+	day, _ := strconv.Atoi(match[1])
+
+	explicitYear := year != 0
+	if !explicitYear {
+		year = today.Year()
+	}
+	date := time.Date(year, time.Month(month+1), day, 0, 0, 0, 0, today.Location())
+	if date.Day() != day {
+		return time.Time{}, false // e.g. feb 30
+	}
+	if !explicitYear && date.Before(today) {
+		date = time.Date(year+1, time.Month(month+1), day, 0, 0, 0, 0, today.Location())
+	}
+	return date, true
+}
+
+// addUnits adds n days, weeks, months or years to a date. Months and years
+// stop at the end of a shorter month: jan 31 + 1 month is feb 28 or 29.
+func addUnits(date time.Time, n int, unit string) (time.Time, bool) {
+	switch unit {
+	case "", "d", "day", "days":
+		return date.AddDate(0, 0, n), true
+	case "w", "wk", "wks", "week", "weeks":
+		return date.AddDate(0, 0, 7*n), true
+	case "m", "mo", "month", "months":
+		return addMonths(date, n), true
+	case "y", "yr", "yrs", "year", "years":
+		return addMonths(date, 12*n), true
+	}
+	return time.Time{}, false
+}
+
+// addMonths adds months to a date, clamping the day to the target month
+func addMonths(date time.Time, n int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(n), 1, 0, 0, 0, 0, date.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	day := date.Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, date.Location())
+}
+
+// startOfWeek returns the Monday of the week containing date
+func startOfWeek(date time.Time) time.Time {
+	return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+}
+
+// countValue reads the count of an offset: a number up to maxCount, "a",
+// "an" or "one"
+func countValue(s string) (int, error) {
+	switch s {
+	case "a", "an", "one":
+		return 1, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n > maxCount {
+		return 0, fmt.Errorf("invalid count %q, use a number up to %d", s, maxCount)
+	}
+	return n, nil
+}
+
+// lookup finds a name by a prefix of at least three letters, e.g. "wed"
+// or "sept", returning its index
+func lookup(names []string, s string) (int, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+	for i, name := range names {
+		if strings.HasPrefix(name, s) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Format renders a due date as Parse reads it back: YYYY-MM-DD, followed by
+// HH:MM when it has a time of day
+func Format(d model.Due) string {
+	if d.AllDay {
+		return d.Time.Format("2006-01-02")
+	}
+	return d.Time.Format("2006-01-02 15:04")
+}
+
+// Describe renders a parsed due date for a preview, with the weekday and how
+// far away it is, e.g. "Fri 23 Oct 2026 (in 4 days)"
+func Describe(d model.Due, now time.Time) string {
+	text := d.Time.Format("Mon 2 Jan 2006")
+	if !d.AllDay {
+		text += d.Time.Format(" 15:04")
+	}
+
+	days := daysBetween(now, d)
+	switch {
+	case days == 0:
+		return text + " (today)"
+	case days == 1:
+		return text + " (tomorrow)"
+	case days == -1:
+		return text + " (yesterday)"
+	case days < 0:
+		return fmt.Sprintf("%s (%d days ago)", text, -days)
+	}
+	return fmt.Sprintf("%s (in %d days)", text, days)
+}
+
+// Urgency ranks how close a due date is, for color coding
+type Urgency int
+
+const (
+	UrgencyLater   Urgency = iota // further away than the soon threshold
+	UrgencySoon                   // within the soon threshold
+	UrgencyToday                  // due later today
+	UrgencyOverdue                // past due, see IsOverdue
+)
+
+// IsOverdue reports whether a due date has passed: its time of day if it
+// has one, otherwise the whole day
+func IsOverdue(d model.Due, now time.Time) bool {
+	if !d.AllDay {
+		return d.Time.Before(now)
+	}
+	return daysBetween(now, d) < 0
+}
+
+// Classify returns the urgency of a due date. Dates up to soonDays days
+// after today are soon.
+func Classify(d model.Due, now time.Time, soonDays int) Urgency {
+	switch days := daysBetween(now, d); {
+	case IsOverdue(d, now):
+		return UrgencyOverdue
+	case days == 0:
+		return UrgencyToday
+	case days <= soonDays:
+		return UrgencySoon
+	}
+	return UrgencyLater
+}
+
+// Relative renders how far a due date is from now in a few characters for
+// cards and reminders: "in 2h", "overdue 3h" or "now". Dates without a time
+// of day count calendar days: "today", "tomorrow", "in 3d", "overdue 2d".
+func Relative(d model.Due, now time.Time) string {
+	if d.AllDay {
+		switch days := daysBetween(now, d); {
+		case days == 0:
+			return "today"
+		case days == 1:
+			return "tomorrow"
+		case days < 0:
+			return fmt.Sprintf("overdue %dd", -days)
+		default:
+			return fmt.Sprintf("in %dd", days)
+		}
+	}
+
+	left := d.Time.Sub(now)
+	switch {
+	case left >= time.Minute:
+		return "in " + span(left)
+	case left > -time.Minute:
+		return "now"
+	}
+	return "overdue " + span(-left)
+}
+
+// span renders a positive duration in its largest whole unit: 45m, 3h or 2d
+func span(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+}
+
+// daysBetween counts the calendar days from now to a due date's day in
+// now's location
+func daysBetween(now time.Time, d model.Due) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	day := d.Day(now.Location())
+	return int(day.Sub(today).Round(time.Hour).Hours() / 24)
+}
@@ -0,0 +1,133 @@
+// Package fuzzy implements subsequence matching with fzf-style scoring for
+// the task finder and command palette.
+package fuzzy
+
+import (
+	"sort"
+	"unicode"
+)
+
+// Scoring weights
+const (
+	scoreMatch       = 16
+	bonusBoundary    = 8 // match at the start of a word
+	bonusFirstChar   = 8 // match at the very start of the text
+	bonusConsecutive = 4 // per preceding adjacent match
+	penaltyGap       = 1 // per skipped character between matches
+	maxGapPenalty    = 8 // cap per gap so long texts are not over-penalized
+)
+
+// Match is a scored fuzzy match of a pattern within a text
+type Match struct {
+	Score     int
+	Positions []int // rune indices of the matched characters
+}
+
+// Result is a ranked candidate
+type Result struct {
+	Index int // index into the candidates
+	Match
+}
+
+// Find matches pattern as a case-insensitive subsequence of text. After the
+// first forward match it scans backwards from its end to find a tighter
+// window, then scores word-boundary and consecutive matches higher.
+func Find(pattern, text string) (Match, bool) {
+	p := []rune(pattern)
+	t := []rune(text)
+	if len(p) == 0 {
+		return Match{}, true
+	}
+
+	// Forward pass: find where the earliest full match ends
+	pi, end := 0, -1
+	for i := 0; i < len(t); i++ {
+		if fold(t[i]) == fold(p[pi]) {
+			pi++
+			if pi == len(p) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return Match{}, false
+	}
+
+	// Backward pass: the latest start that still matches, for a tight window
+	positions := make([]int, len(p))
+	pi = len(p) - 1
+	for i := end; i >= 0 && pi >= 0; i-- {
+		if fold(t[i]) == fold(p[pi]) {
+			positions[pi] = i
+			pi--
+		}
+	}
+
+	return Match{Score: score(t, positions), Positions: positions}, true
+}
+
+// Rank matches pattern against every candidate and returns the matches best
+// first; ties keep candidate order. An empty pattern returns every candidate.
+func Rank(pattern string, candidates []string) []Result {
+	var results []Result
+	for i, c := range candidates {
+		if m, ok := Find(pattern, c); ok {
+			results = append(results, Result{Index: i, Match: m})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results
+}
+
+// score rates matched positions in text
+func score(text []rune, positions []int) int {
+	total := 0
+	for i, pos := range positions {
+		total += scoreMatch
+		if pos == 0 {
+			total += bonusFirstChar
+		}
+		if isBoundary(text, pos) {
+			total += bonusBoundary
+		}
+		if i > 0 {
+			if gap := pos - positions[i-1] - 1; gap == 0 {
+				total += bonusConsecutive * consecutiveRun(positions, i)
+			} else {
+				penalty := gap * penaltyGap
+				if penalty > maxGapPenalty {
+					penalty = maxGapPenalty
+				}
+				total -= penalty
+			}
+		}
+	}
+	return total
+}
+
+// consecutiveRun counts adjacent matches directly before positions[i]
+func consecutiveRun(positions []int, i int) int {
+	run := 0
+	for j := i; j > 0 && positions[j] == positions[j-1]+1; j-- {
+		run++
+	}
+	return run
+}
+
+// isBoundary reports whether text[pos] starts a word: after a separator or
+// at a lower-to-upper case change
+func isBoundary(text []rune, pos int) bool {
+	if pos == 0 {
+		return true
+	}
+	prev, cur := text[pos-1], text[pos]
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+func fold(r rune) rune {
+	return unicode.ToLower(r)
+}
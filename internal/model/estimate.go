@@ -0,0 +1,26 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// EstimateUnit is the unit task estimates are expressed in
+type EstimateUnit string
+
+const (
+	EstimatePoints EstimateUnit = "points"
+	EstimateHours  EstimateUnit = "hours"
+)
+
+// Format renders an estimate value with its unit, e.g. "3 pts" or "2.5h"
+func (u EstimateUnit) Format(v float64) string {
+	num := strconv.FormatFloat(v, 'f', -1, 64)
+	if u == EstimateHours {
+		return num + "h"
+	}
+	if v == 1 {
+		return num + " pt"
+	}
+	return fmt.Sprintf("%s pts", num)
+}
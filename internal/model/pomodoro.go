@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Pomodoro lengths for focus mode
+const (
+	PomodoroWork  = 25 * time.Minute
+	PomodoroBreak = 5 * time.Minute
+)
+
+// Pomodoro represents a completed focus interval on a task
+type Pomodoro struct {
+	ID          int64     `json:"id"`
+	TaskID      int64     `json:"task_id"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// Sprint represents a time-boxed iteration tasks can be planned into
+type Sprint struct {
+	ID       int64      `json:"id"`
+	Name     string     `json:"name"`
+	Start    time.Time  `json:"start"`
+	End      time.Time  `json:"end"`
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+}
+
+// Closed reports whether the sprint has been closed
+func (s Sprint) Closed() bool {
+	return s.ClosedAt != nil
+}
+
+// Contains reports whether t falls within the sprint's start and end dates (inclusive)
+func (s Sprint) Contains(t time.Time) bool {
+	endOfDay := s.End.AddDate(0, 0, 1)
+	return !t.Before(s.Start) && t.Before(endOfDay)
+}
+
+// Carryover records an unfinished task moved from one sprint to the next on close
+type Carryover struct {
+	TaskID       int64     `json:"task_id"`
+	FromSprintID int64     `json:"from_sprint_id"`
+	ToSprintID   int64     `json:"to_sprint_id"`
+	CarriedAt    time.Time `json:"carried_at"`
+}
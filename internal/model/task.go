@@ -17,10 +17,110 @@ type Task struct {
 	Title       string     `json:"title"`
 	Description string     `json:"description"`
 	Tags        []string   `json:"tags"`
-	Due         *time.Time `json:"due,omitempty"`
+	Due         *Due       `json:"due,omitempty"`
+	Estimate    *float64   `json:"estimate,omitempty"` // points or hours, see EstimateUnit
 	Status      TaskStatus `json:"status"`
+	SprintID    *int64     `json:"sprint_id,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// StatusChangedAt is when the task entered its current column; unlike
+	// UpdatedAt it is not bumped by other edits
+	StatusChangedAt time.Time `json:"status_changed_at"`
+
+	// ArchivedAt is set once the task is archived off the board
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
+	// Time tracking, derived from the task's time entries
+	Tracked    time.Duration `json:"tracked"`               // total of stopped entries
+	TimerStart *time.Time    `json:"timer_start,omitempty"` // start of the running entry, if any
+	Pomodoros  int           `json:"pomodoros"`             // completed focus intervals
+}
+
+// TaskEdit holds the fields of a task that are edited together, e.g. in
+// an external editor
+type TaskEdit struct {
+	Title       string
+	Status      TaskStatus
+	Tags        []string
+	Due         *Due
+	Description string
+}
+
+// Edit returns the task's editable fields
+func (t Task) Edit() TaskEdit {
+	return TaskEdit{
+		Title:       t.Title,
+		Status:      t.Status,
+		Tags:        append([]string(nil), t.Tags...),
+		Due:         t.Due,
+		Description: t.Description,
+	}
+}
+
+// Equal reports whether two edits set the same values. Due times are
+// compared to the minute.
+func (e TaskEdit) Equal(o TaskEdit) bool {
+	if e.Title != o.Title || e.Status != o.Status || e.Description != o.Description {
+		return false
+	}
+	if len(e.Tags) != len(o.Tags) {
+		return false
+	}
+	for i := range e.Tags {
+		if e.Tags[i] != o.Tags[i] {
+			return false
+		}
+	}
+	if (e.Due == nil) != (o.Due == nil) {
+		return false
+	}
+	return e.Due == nil || e.Due.Equal(*o.Due)
+}
+
+// Due is when a task is due: a calendar day, or an instant when it has a
+// time of day. A day stays the same day in every timezone.
+type Due struct {
+	// Time is the due instant, or midnight of the day in the local
+	// timezone for an all-day due date
+	Time   time.Time `json:"time"`
+	AllDay bool      `json:"all_day"`
+}
+
+// Date returns an all-day due date on the given day
+func Date(year int, month time.Month, day int) Due {
+	return Due{Time: time.Date(year, month, day, 0, 0, 0, 0, time.Local), AllDay: true}
+}
+
+// Day returns midnight of the due date's day in loc. A due time falls on
+// its day in loc; an all-day date is the same day everywhere.
+func (d Due) Day(loc *time.Location) time.Time {
+	t := d.Time
+	if !d.AllDay {
+		t = t.In(loc)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// Equal reports whether two due dates are the same day, or the same time to
+// the minute
+func (d Due) Equal(o Due) bool {
+	if d.AllDay != o.AllDay {
+		return false
+	}
+	if d.AllDay {
+		return d.Time.Format("2006-01-02") == o.Time.Format("2006-01-02")
+	}
+	return d.Time.Truncate(time.Minute).Equal(o.Time.Truncate(time.Minute))
+}
+
+// TrackedAt returns the total tracked time including a running timer up to now
+func (t Task) TrackedAt(now time.Time) time.Duration {
+	total := t.Tracked
+	if t.TimerStart != nil && now.After(*t.TimerStart) {
+		total += now.Sub(*t.TimerStart)
+	}
+	return total
 }
 
 // Column represents a kanban column
@@ -62,3 +162,11 @@ func (s TaskStatus) Prev() TaskStatus {
 		return StatusTodo
 	}
 }
+
+// StatusTransition records a task moving between columns
+type StatusTransition struct {
+	TaskID    int64      `json:"task_id"`
+	From      TaskStatus `json:"from"` // empty for the transition that created the task
+	To        TaskStatus `json:"to"`   // empty for the transition that deleted the task
+	ChangedAt time.Time  `json:"changed_at"`
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ViewSort orders tasks within each column
+type ViewSort string
+
+const (
+	SortCreated  ViewSort = "created"  // newest first (the board's default)
+	SortUpdated  ViewSort = "updated"  // most recently updated first
+	SortDue      ViewSort = "due"      // earliest due first, undated last
+	SortEstimate ViewSort = "estimate" // largest first, unestimated last
+	SortTitle    ViewSort = "title"    // alphabetical
+	SortAge      ViewSort = "age"      // longest in its column first
+)
+
+// ViewSorts lists the valid sort orders
+var ViewSorts = []ViewSort{SortCreated, SortUpdated, SortDue, SortEstimate, SortTitle, SortAge}
+
+// ParseViewSort validates a sort name; empty means the default order
+func ParseViewSort(s string) (ViewSort, error) {
+	if s == "" {
+		return SortCreated, nil
+	}
+	for _, v := range ViewSorts {
+		if string(v) == s {
+			return v, nil
+		}
+	}
+	names := make([]string, len(ViewSorts))
+	for i, v := range ViewSorts {
+		names[i] = string(v)
+	}
+	return "", fmt.Errorf("unknown sort %q (use %s)", s, strings.Join(names, ", "))
+}
+
+// SortTasks orders tasks in place
+func SortTasks(tasks []Task, by ViewSort) {
+	var less func(a, b Task) bool
+	switch by {
+	case SortUpdated:
+		less = func(a, b Task) bool { return a.UpdatedAt.After(b.UpdatedAt) }
+	case SortDue:
+		less = func(a, b Task) bool {
+			if a.Due == nil || b.Due == nil {
+				return a.Due != nil && b.Due == nil
+			}
+			return a.Due.Time.Before(b.Due.Time)
+		}
+	case SortEstimate:
+		less = func(a, b Task) bool {
+			if a.Estimate == nil || b.Estimate == nil {
+				return a.Estimate != nil && b.Estimate == nil
+			}
+			return *a.Estimate > *b.Estimate
+		}
+	case SortTitle:
+		less = func(a, b Task) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	case SortAge:
+		less = func(a, b Task) bool { return a.StatusChangedAt.Before(b.StatusChangedAt) }
+	default:
+		less = func(a, b Task) bool { return a.CreatedAt.After(b.CreatedAt) }
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return less(tasks[i], tasks[j]) })
+}
+
+// ParseStatus validates a status name
+func ParseStatus(s string) (TaskStatus, error) {
+	switch TaskStatus(s) {
+	case StatusTodo, StatusInProgress, StatusDone:
+		return TaskStatus(s), nil
+	}
+	return "", fmt.Errorf("unknown status %q (use todo, in_progress, done)", s)
+}
+
+// SavedView is a named search filter with display options
+type SavedView struct {
+	ID        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Query     string       `json:"query"`
+	Sort      ViewSort     `json:"sort"`
+	Columns   []TaskStatus `json:"columns,omitempty"` // columns shown; empty shows all
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// ShowsColumn reports whether the view displays the column with status
+func (v SavedView) ShowsColumn(status TaskStatus) bool {
+	if len(v.Columns) == 0 {
+		return true
+	}
+	for _, s := range v.Columns {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/due"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// dueKeywords are the due: values other than dates
+var dueKeywords = []string{"none", "today", "yesterday", "tomorrow", "overdue"}
+
+// statusKeywords are the status: values
+var statusKeywords = []string{string(model.StatusTodo), string(model.StatusInProgress), string(model.StatusDone)}
+
+// comparisons are the operators accepted before due: and est: values,
+// longest first so "<=" is not read as "<"
+var comparisons = []string{"<=", ">=", "<", ">", "="}
+
+// splitComparison splits a leading comparison operator off value
+func splitComparison(value string) (string, string) {
+	for _, op := range comparisons {
+		if strings.HasPrefix(value, op) {
+			return op, strings.TrimPrefix(value, op)
+		}
+	}
+	return "=", value
+}
+
+// validateTerm checks due:, status: and est: values when the query is
+// parsed, so mistakes surface as errors rather than empty results
+func validateTerm(t Term) error {
+	if t.Value == "" {
+		return nil
+	}
+	switch t.Field {
+	case "due":
+		for _, kw := range dueKeywords {
+			if strings.EqualFold(t.Value, kw) {
+				return nil
+			}
+		}
+		_, date := splitComparison(t.Value)
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return &Error{Pos: t.Pos, Msg: fmt.Sprintf("due: expects YYYY-MM-DD or %s", strings.Join(dueKeywords, "/"))}
+		}
+	case "status":
+		for _, kw := range statusKeywords {
+			if strings.EqualFold(t.Value, kw) {
+				return nil
+			}
+		}
+		return &Error{Pos: t.Pos, Msg: fmt.Sprintf("status: expects %s", strings.Join(statusKeywords, "/"))}
+	case "est":
+		if strings.EqualFold(t.Value, "none") {
+			return nil
+		}
+		_, num := splitComparison(t.Value)
+		if _, err := strconv.ParseFloat(num, 64); err != nil {
+			return &Error{Pos: t.Pos, Msg: "est: expects a number or none"}
+		}
+	}
+	return nil
+}
+
+// matchTerm evaluates one term against a task. A field with an empty value
+// matches everything, so a half-typed "tag:" does not hide the board.
+func matchTerm(t Term, task model.Task, env Env) bool {
+	if t.Value == "" {
+		return true
+	}
+
+	switch t.Field {
+	case "title":
+		return containsFold(task.Title, t.Value)
+
+	case "desc":
+		return containsFold(task.Description, t.Value)
+
+	case "tag":
+		for _, tag := range task.Tags {
+			if strings.EqualFold(tag, t.Value) {
+				return true
+			}
+		}
+		return false
+
+	case "due":
+		return matchDue(t.Value, task.Due, env.Now)
+
+	case "status":
+		return strings.EqualFold(string(task.Status), t.Value)
+
+	case "est":
+		return matchEstimate(t.Value, task.Estimate)
+	}
+
+	// Bare text: use full-text results when available
+	if hits, ok := env.TextHits[t.SearchText()]; ok {
+		return hits[task.ID]
+	}
+	if containsFold(task.Title, t.Value) || containsFold(task.Description, t.Value) {
+		return true
+	}
+	for _, tag := range task.Tags {
+		if containsFold(tag, t.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDue compares a due date by calendar day in now's location; an
+// all-day date is compared as its own day. A due date with a time of day is
+// overdue once that time has passed.
+func matchDue(value string, dueAt *model.Due, now time.Time) bool {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	value = strings.ToLower(value)
+	if value == "none" {
+		return dueAt == nil
+	}
+	if dueAt == nil {
+		return false
+	}
+	day := dueAt.Day(loc)
+
+	switch value {
+	case "today":
+		return day.Equal(today)
+	case "yesterday":
+		return day.Equal(today.AddDate(0, 0, -1))
+	case "tomorrow":
+		return day.Equal(today.AddDate(0, 0, 1))
+	case "overdue":
+		return due.IsOverdue(*dueAt, now)
+	}
+
+	op, dateStr := splitComparison(value)
+	date, err := time.ParseInLocation("2006-01-02", dateStr, loc)
+	if err != nil {
+		return false
+	}
+	switch op {
+	case "<=":
+		return !day.After(date)
+	case ">=":
+		return !day.Before(date)
+	case "<":
+		return day.Before(date)
+	case ">":
+		return day.After(date)
+	}
+	return day.Equal(date)
+}
+
+// matchEstimate compares an estimate against a number
+func matchEstimate(value string, estimate *float64) bool {
+	if strings.EqualFold(value, "none") {
+		return estimate == nil
+	}
+	if estimate == nil {
+		return false
+	}
+
+	op, num := splitComparison(value)
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return false
+	}
+	switch op {
+	case "<=":
+		return *estimate <= v
+	case ">=":
+		return *estimate >= v
+	case "<":
+		return *estimate < v
+	case ">":
+		return *estimate > v
+	}
+	return *estimate == v
+}
@@ -0,0 +1,229 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokTerm
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+)
+
+type token struct {
+	kind tokenKind
+	term Term
+	pos  int
+}
+
+// Parse parses a filter query. An empty query matches every task.
+func Parse(input string) (*Query, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return &Query{}, nil
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		if tok.kind == tokRParen {
+			return nil, &Error{Pos: tok.pos, Msg: "unexpected )"}
+		}
+		return nil, &Error{Pos: tok.pos, Msg: "unexpected input"}
+	}
+	return &Query{root: root}, nil
+}
+
+// lex splits the input into tokens. AND, OR and NOT are operators only in
+// upper case; quote them to search for the words.
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, pos: pos})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && runes[i+1] != ')':
+			tokens = append(tokens, token{kind: tokNot, pos: pos})
+			i++
+		case r == '"':
+			value, next, err := readQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokTerm, pos: pos, term: Term{Value: value, Phrase: true, Pos: pos}})
+			i = next
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			word := string(runes[start:i])
+
+			switch word {
+			case "AND":
+				tokens = append(tokens, token{kind: tokAnd, pos: pos})
+				continue
+			case "OR":
+				tokens = append(tokens, token{kind: tokOr, pos: pos})
+				continue
+			case "NOT":
+				tokens = append(tokens, token{kind: tokNot, pos: pos})
+				continue
+			}
+
+			term := Term{Value: word, Pos: pos}
+			// Only known field names are prefixes; "10:30" or a URL is text
+			if field, value, ok := strings.Cut(word, ":"); ok && isField(strings.ToLower(field)) {
+				field = strings.ToLower(field)
+				term.Field, term.Value = field, value
+				// A quote right after the colon quotes the value
+				if value == "" && i < len(runes) && runes[i] == '"' {
+					quoted, next, err := readQuoted(runes, i)
+					if err != nil {
+						return nil, err
+					}
+					term.Value, term.Phrase = quoted, true
+					i = next
+				}
+				if err := validateTerm(term); err != nil {
+					return nil, err
+				}
+			}
+			tokens = append(tokens, token{kind: tokTerm, pos: pos, term: term})
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(runes) + 1}), nil
+}
+
+// readQuoted reads a quoted string starting at runes[start] == '"' and
+// returns its contents and the index after the closing quote
+func readQuoted(runes []rune, start int) (string, int, error) {
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '"' {
+			return string(runes[start+1 : i]), i + 1, nil
+		}
+	}
+	return "", 0, &Error{Pos: start + 1, Msg: "unterminated quote"}
+}
+
+func isField(name string) bool {
+	for _, f := range Fields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseOr parses: and ("OR" and)*
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary (["AND"] unary)*
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokAnd:
+			p.next()
+		case tokTerm, tokNot, tokLParen:
+		default:
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+// parseUnary parses: ("NOT" | "-") unary | "(" or ")" | term
+func (p *parser) parseUnary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNot:
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &Error{Pos: closing.pos, Msg: fmt.Sprintf("missing ) for ( at column %d", tok.pos)}
+		}
+		return inner, nil
+
+	case tokTerm:
+		return termNode{tok.term}, nil
+
+	case tokEOF:
+		return nil, &Error{Pos: tok.pos, Msg: "unexpected end of query"}
+	case tokRParen:
+		return nil, &Error{Pos: tok.pos, Msg: "unexpected )"}
+	default:
+		return nil, &Error{Pos: tok.pos, Msg: "expected a search term"}
+	}
+}
@@ -0,0 +1,189 @@
+// Package query parses and evaluates the board's search filter language.
+//
+// Terms are combined with AND (implicit or explicit), OR and NOT (or a
+// leading -), grouped with parentheses. A term is a word, a "quoted phrase"
+// or a field prefix followed by a value, e.g.
+//
+//	tag:bug (due:overdue OR due:today) -title:"spike"
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// Fields are the supported field prefixes
+var Fields = []string{"title", "desc", "tag", "due", "status", "est"}
+
+// Error is a syntax error at a position in the query
+type Error struct {
+	Pos int // 1-based character column
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos, e.Msg)
+}
+
+// Term is a single condition: a bare word or phrase, or a field value
+type Term struct {
+	Field  string // "" for bare text matched against title, description and tags
+	Value  string
+	Phrase bool // value was quoted
+	Pos    int
+}
+
+// SearchText returns the term in full-text search syntax, used as the key
+// of Env.TextHits
+func (t Term) SearchText() string {
+	if t.Phrase {
+		return `"` + t.Value + `"`
+	}
+	return t.Value
+}
+
+// Env is the context a query is evaluated in
+type Env struct {
+	Now time.Time
+	// TextHits holds full-text index results for bare terms keyed by
+	// SearchText; terms without an entry fall back to substring matching
+	TextHits map[string]map[int64]bool
+}
+
+// Query is a parsed filter
+type Query struct {
+	root node // nil matches everything
+}
+
+// node is an expression in the query tree
+type node interface {
+	match(task model.Task, env Env) bool
+}
+
+type andNode struct{ left, right node }
+
+type orNode struct{ left, right node }
+
+type notNode struct{ inner node }
+
+type termNode struct{ term Term }
+
+func (n andNode) match(task model.Task, env Env) bool {
+	return n.left.match(task, env) && n.right.match(task, env)
+}
+
+func (n orNode) match(task model.Task, env Env) bool {
+	return n.left.match(task, env) || n.right.match(task, env)
+}
+
+func (n notNode) match(task model.Task, env Env) bool {
+	return !n.inner.match(task, env)
+}
+
+func (n termNode) match(task model.Task, env Env) bool {
+	return matchTerm(n.term, task, env)
+}
+
+// Match reports whether a task satisfies the query
+func (q *Query) Match(task model.Task, env Env) bool {
+	if q == nil || q.root == nil {
+		return true
+	}
+	return q.root.match(task, env)
+}
+
+// Empty reports whether the query has no conditions
+func (q *Query) Empty() bool {
+	return q == nil || q.root == nil
+}
+
+// TextTerms returns the bare word and phrase terms, which full-text search
+// can answer
+func (q *Query) TextTerms() []Term {
+	var terms []Term
+	if q != nil {
+		collectTextTerms(q.root, &terms)
+	}
+	return terms
+}
+
+func collectTextTerms(n node, terms *[]Term) {
+	switch n := n.(type) {
+	case andNode:
+		collectTextTerms(n.left, terms)
+		collectTextTerms(n.right, terms)
+	case orNode:
+		collectTextTerms(n.left, terms)
+		collectTextTerms(n.right, terms)
+	case notNode:
+		collectTextTerms(n.inner, terms)
+	case termNode:
+		if n.term.Field == "" {
+			*terms = append(*terms, n.term)
+		}
+	}
+}
+
+// Highlights returns the terms that can explain a match in field ("title",
+// "desc" or "tag"): bare text terms and terms on that field, skipping any
+// under NOT
+func (q *Query) Highlights(field string) []Term {
+	var terms []Term
+	if q != nil {
+		collectHighlights(q.root, field, false, &terms)
+	}
+	return terms
+}
+
+func collectHighlights(n node, field string, negated bool, terms *[]Term) {
+	switch n := n.(type) {
+	case andNode:
+		collectHighlights(n.left, field, negated, terms)
+		collectHighlights(n.right, field, negated, terms)
+	case orNode:
+		collectHighlights(n.left, field, negated, terms)
+		collectHighlights(n.right, field, negated, terms)
+	case notNode:
+		collectHighlights(n.inner, field, !negated, terms)
+	case termNode:
+		if !negated && n.term.Value != "" && (n.term.Field == "" || n.term.Field == field) {
+			*terms = append(*terms, n.term)
+		}
+	}
+}
+
+// TextHits runs search for each distinct text term, building Env.TextHits
+func (q *Query) TextHits(search func(text string) (map[int64]bool, error)) (map[string]map[int64]bool, error) {
+	hits := make(map[string]map[int64]bool)
+	for _, t := range q.TextTerms() {
+		key := t.SearchText()
+		if _, ok := hits[key]; ok {
+			continue
+		}
+		ids, err := search(key)
+		if err != nil {
+			return nil, err
+		}
+		hits[key] = ids
+	}
+	return hits, nil
+}
+
+// Filter returns the tasks matching the query
+func (q *Query) Filter(tasks []model.Task, env Env) []model.Task {
+	var filtered []model.Task
+	for _, t := range tasks {
+		if q.Match(t, env) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// containsFold reports whether substr is within s, ignoring case
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
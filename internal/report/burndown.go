@@ -0,0 +1,82 @@
+package report
+
+import (
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// BurndownPoint is the remaining work at the end of one sprint day
+type BurndownPoint struct {
+	Day       time.Time
+	Remaining float64
+	Ideal     float64
+	Future    bool // day has not happened yet; only Ideal is meaningful
+}
+
+// Burndown computes remaining work per day of a sprint from status history.
+// scope is the set of tasks planned in the sprint (including ones carried out
+// of it on close) and weight gives each task's size, e.g. 1 or its estimate.
+func Burndown(sprint model.Sprint, scope []model.Task, timelines map[int64]Timeline, weight func(model.Task) float64, now time.Time) []BurndownPoint {
+	days := Days(sprint.Start, sprint.End)
+	points := make([]BurndownPoint, len(days))
+
+	for i, day := range days {
+		points[i].Day = day
+		if day.After(now) {
+			points[i].Future = true
+			continue
+		}
+		at := dayEnd(day, now)
+		for _, task := range scope {
+			status, ok := timelines[task.ID].StatusAt(at)
+			if ok && status != model.StatusDone {
+				points[i].Remaining += weight(task)
+			}
+		}
+	}
+
+	// Ideal line runs from the first day's remaining work to zero on the last day
+	if len(points) > 0 {
+		start := points[0].Remaining
+		for i := range points {
+			if len(points) == 1 {
+				points[i].Ideal = 0
+				continue
+			}
+			points[i].Ideal = start * float64(len(points)-1-i) / float64(len(points)-1)
+		}
+	}
+
+	return points
+}
+
+// SprintScope returns the tasks planned in a sprint: those assigned to it and
+// those carried out of it when it was closed
+func SprintScope(sprint model.Sprint, tasks []model.Task, carryovers []model.Carryover) []model.Task {
+	carried := make(map[int64]bool, len(carryovers))
+	for _, c := range carryovers {
+		carried[c.TaskID] = true
+	}
+
+	var scope []model.Task
+	for _, t := range tasks {
+		if t.SprintID != nil && *t.SprintID == sprint.ID || carried[t.ID] {
+			scope = append(scope, t)
+		}
+	}
+	return scope
+}
+
+// CountWeight sizes every task as 1
+func CountWeight(model.Task) float64 {
+	return 1
+}
+
+// EstimateWeight sizes tasks by their estimate, treating unestimated tasks as 0
+func EstimateWeight(t model.Task) float64 {
+	if t.Estimate == nil {
+		return 0
+	}
+	return *t.Estimate
+}
@@ -0,0 +1,36 @@
+package report
+
+import (
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// CFDPoint is the number of tasks in each status at the end of one day
+type CFDPoint struct {
+	Day    time.Time
+	Counts map[model.TaskStatus]int
+}
+
+// CumulativeFlow counts tasks per status at the end of each day from since
+// through until, using the status history of every task
+func CumulativeFlow(timelines map[int64]Timeline, since, until, now time.Time) []CFDPoint {
+	days := Days(since, until)
+	points := make([]CFDPoint, 0, len(days))
+
+	for _, day := range days {
+		if day.After(now) {
+			break
+		}
+		at := dayEnd(day, now)
+		counts := make(map[model.TaskStatus]int)
+		for _, tl := range timelines {
+			if status, ok := tl.StatusAt(at); ok {
+				counts[status]++
+			}
+		}
+		points = append(points, CFDPoint{Day: day, Counts: counts})
+	}
+
+	return points
+}
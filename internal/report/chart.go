@@ -0,0 +1,226 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// eighths are the partial block characters for bar tops, from 1/8 to 7/8 full
+var eighths = []rune("▁▂▃▄▅▆▇")
+
+// idealMark is drawn where the ideal burndown line passes through an empty cell
+const idealMark = '·'
+
+// maxColumnWidth caps how wide a single data point is drawn
+const maxColumnWidth = 4
+
+// Band is one stacked series of a cumulative flow chart
+type Band struct {
+	Status model.TaskStatus
+	Label  string
+	Fill   rune
+}
+
+// DefaultBands stacks Done at the bottom, then In Progress, then Todo
+func DefaultBands() []Band {
+	return []Band{
+		{Status: model.StatusDone, Label: "Done", Fill: '█'},
+		{Status: model.StatusInProgress, Label: "In Progress", Fill: '▓'},
+		{Status: model.StatusTodo, Label: "Todo", Fill: '░'},
+	}
+}
+
+// BurndownChart renders remaining work as vertical bars with the ideal line
+// dotted over them. width and height size the plot area, excluding axes.
+func BurndownChart(points []BurndownPoint, width, height int) string {
+	if len(points) == 0 || width < 1 || height < 1 {
+		return "No data\n"
+	}
+
+	maxValue := 0.0
+	for _, p := range points {
+		maxValue = math.Max(maxValue, math.Max(p.Remaining, p.Ideal))
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+
+	cols := layoutColumns(len(points), width, true)
+	cells := newCells(height, len(cols))
+	for c, idx := range cols {
+		if idx < 0 {
+			continue
+		}
+		p := points[idx]
+		if !p.Future {
+			fillBar(cells, c, p.Remaining/maxValue)
+		}
+		row := height - 1 - int(math.Min(p.Ideal/maxValue*float64(height), float64(height-1)))
+		if cells[row][c] == ' ' {
+			cells[row][c] = idealMark
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(renderGrid(cells, maxValue, points[0].Day, points[len(points)-1].Day))
+	b.WriteString(fmt.Sprintf("█ remaining  %c ideal\n", idealMark))
+	return b.String()
+}
+
+// CFDChart renders task counts per status as a stacked area chart, stacking
+// bands bottom-up in the given order. width and height size the plot area.
+func CFDChart(points []CFDPoint, bands []Band, width, height int) string {
+	if len(points) == 0 || width < 1 || height < 1 {
+		return "No data\n"
+	}
+
+	maxTotal := 0
+	for _, p := range points {
+		total := 0
+		for _, band := range bands {
+			total += p.Counts[band.Status]
+		}
+		if total > maxTotal {
+			maxTotal = total
+		}
+	}
+	if maxTotal == 0 {
+		maxTotal = 1
+	}
+
+	cols := layoutColumns(len(points), width, false)
+	cells := newCells(height, len(cols))
+	for c, idx := range cols {
+		p := points[idx]
+		for r := 0; r < height; r++ {
+			// Sample the middle of the cell, counting rows from the bottom
+			y := (float64(r) + 0.5) / float64(height) * float64(maxTotal)
+			cum := 0
+			for _, band := range bands {
+				cum += p.Counts[band.Status]
+				if y < float64(cum) {
+					cells[height-1-r][c] = band.Fill
+					break
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(renderGrid(cells, float64(maxTotal), points[0].Day, points[len(points)-1].Day))
+	legend := make([]string, len(bands))
+	for i, band := range bands {
+		legend[i] = fmt.Sprintf("%c %s", band.Fill, band.Label)
+	}
+	b.WriteString(strings.Join(legend, "  "))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// layoutColumns maps n data points onto at most width chart columns and
+// returns the point index drawn in each column. Wide points repeat their
+// index; with gaps, the last column of each point is left empty (-1).
+func layoutColumns(n, width int, gaps bool) []int {
+	if n <= width {
+		colWidth := width / n
+		if colWidth > maxColumnWidth {
+			colWidth = maxColumnWidth
+		}
+		cols := make([]int, 0, n*colWidth)
+		for i := 0; i < n; i++ {
+			for j := 0; j < colWidth; j++ {
+				if gaps && colWidth > 1 && j == colWidth-1 {
+					cols = append(cols, -1)
+					continue
+				}
+				cols = append(cols, i)
+			}
+		}
+		return cols
+	}
+
+	// More points than columns: sample evenly, always keeping the last point
+	cols := make([]int, width)
+	for c := range cols {
+		cols[c] = (c + 1) * n / width
+		if cols[c] > 0 {
+			cols[c]--
+		}
+	}
+	return cols
+}
+
+// newCells returns a blank height x width grid
+func newCells(height, width int) [][]rune {
+	cells := make([][]rune, height)
+	for r := range cells {
+		cells[r] = []rune(strings.Repeat(" ", width))
+	}
+	return cells
+}
+
+// fillBar draws a bar in column c filling fraction of the grid height
+func fillBar(cells [][]rune, c int, fraction float64) {
+	height := len(cells)
+	filled := int(math.Round(fraction * float64(height*8)))
+	for r := 0; r < height; r++ {
+		fill := filled - r*8
+		row := height - 1 - r
+		switch {
+		case fill >= 8:
+			cells[row][c] = '█'
+		case fill > 0:
+			cells[row][c] = eighths[fill-1]
+		}
+	}
+}
+
+// renderGrid draws the cells with a y axis labelled 0..maxValue and an x axis
+// labelled with the first and last day
+func renderGrid(cells [][]rune, maxValue float64, first, last time.Time) string {
+	height := len(cells)
+	width := 0
+	if height > 0 {
+		width = len(cells[0])
+	}
+
+	top := formatValue(maxValue)
+	labelWidth := len(top)
+
+	var b strings.Builder
+	for r, row := range cells {
+		label := ""
+		switch r {
+		case 0:
+			label = top
+		case height - 1:
+			label = "0"
+		}
+		b.WriteString(fmt.Sprintf("%*s │%s\n", labelWidth, label, string(row)))
+	}
+	b.WriteString(strings.Repeat(" ", labelWidth+1))
+	b.WriteString("└")
+	b.WriteString(strings.Repeat("─", width))
+	b.WriteString("\n")
+
+	firstLabel := first.Format("Jan 2")
+	lastLabel := last.Format("Jan 2")
+	b.WriteString(strings.Repeat(" ", labelWidth+2))
+	b.WriteString(firstLabel)
+	if pad := width - len(firstLabel) - len(lastLabel); pad > 0 && !first.Equal(last) {
+		b.WriteString(strings.Repeat(" ", pad))
+		b.WriteString(lastLabel)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatValue formats an axis value without trailing zeros
+func formatValue(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
@@ -0,0 +1,144 @@
+package report
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// TaskFlow is the lead and cycle time of one completed task
+type TaskFlow struct {
+	Task      model.Task
+	CreatedAt time.Time
+	StartedAt *time.Time // first move into In Progress; nil if it skipped the column
+	DoneAt    time.Time
+	LeadTime  time.Duration // created → done
+	CycleTime time.Duration // first in progress → done; 0 without StartedAt
+}
+
+// AgingItem is a task currently in progress and how long since it started
+type AgingItem struct {
+	Task      model.Task
+	StartedAt time.Time
+	Age       time.Duration
+}
+
+// WeekThroughput is the number of tasks completed in a week starting Monday
+type WeekThroughput struct {
+	WeekStart time.Time
+	Count     int
+}
+
+// Percentiles summarizes a distribution of durations
+type Percentiles struct {
+	P50 time.Duration
+	P85 time.Duration
+	P95 time.Duration
+}
+
+// FlowReport holds flow metrics derived from status history
+type FlowReport struct {
+	Completed  []TaskFlow
+	LeadTime   Percentiles
+	CycleTime  Percentiles
+	Throughput []WeekThroughput
+	Aging      []AgingItem
+}
+
+// Flow computes lead time, cycle time, weekly throughput and aging work in
+// progress for tasks. Completed tasks count when they were done on or after
+// since (zero for all time); aging covers every task currently in progress.
+func Flow(tasks []model.Task, timelines map[int64]Timeline, since, now time.Time) FlowReport {
+	var r FlowReport
+	var leads, cycles []time.Duration
+
+	for _, task := range tasks {
+		tl := timelines[task.ID]
+		switch task.Status {
+		case model.StatusDone:
+			doneAt, ok := tl.LastEntered(model.StatusDone)
+			if !ok || doneAt.Before(since) {
+				continue
+			}
+			created, ok := tl.Created()
+			if !ok {
+				created = task.CreatedAt
+			}
+			f := TaskFlow{Task: task, CreatedAt: created, DoneAt: doneAt, LeadTime: doneAt.Sub(created)}
+			if started, ok := tl.FirstEntered(model.StatusInProgress); ok && !started.After(doneAt) {
+				f.StartedAt = &started
+				f.CycleTime = doneAt.Sub(started)
+				cycles = append(cycles, f.CycleTime)
+			}
+			leads = append(leads, f.LeadTime)
+			r.Completed = append(r.Completed, f)
+
+		case model.StatusInProgress:
+			started, ok := tl.FirstEntered(model.StatusInProgress)
+			if !ok {
+				started = task.CreatedAt
+			}
+			r.Aging = append(r.Aging, AgingItem{Task: task, StartedAt: started, Age: now.Sub(started)})
+		}
+	}
+
+	sort.Slice(r.Completed, func(i, j int) bool { return r.Completed[i].DoneAt.Before(r.Completed[j].DoneAt) })
+	sort.Slice(r.Aging, func(i, j int) bool { return r.Aging[i].Age > r.Aging[j].Age })
+
+	r.LeadTime = percentiles(leads)
+	r.CycleTime = percentiles(cycles)
+	r.Throughput = weeklyThroughput(r.Completed, since, now)
+	return r
+}
+
+// percentiles computes p50/p85/p95 using the nearest-rank method
+func percentiles(durations []time.Duration) Percentiles {
+	if len(durations) == 0 {
+		return Percentiles{}
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p float64) time.Duration {
+		i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	return Percentiles{P50: rank(50), P85: rank(85), P95: rank(95)}
+}
+
+// weeklyThroughput counts completions per week from the week of since (or of
+// the first completion) through the current week
+func weeklyThroughput(completed []TaskFlow, since, now time.Time) []WeekThroughput {
+	start := since
+	if start.IsZero() {
+		if len(completed) == 0 {
+			return nil
+		}
+		start = completed[0].DoneAt
+	}
+
+	var weeks []WeekThroughput
+	index := make(map[time.Time]int)
+	for w := weekStart(start); !w.After(now); w = w.AddDate(0, 0, 7) {
+		index[w] = len(weeks)
+		weeks = append(weeks, WeekThroughput{WeekStart: w})
+	}
+	for _, f := range completed {
+		if i, ok := index[weekStart(f.DoneAt)]; ok {
+			weeks[i].Count++
+		}
+	}
+	return weeks
+}
+
+// weekStart returns local midnight of the Monday starting t's week
+func weekStart(t time.Time) time.Time {
+	d := dayStart(t)
+	offset := (int(d.Weekday()) + 6) % 7 // days since Monday
+	return d.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,87 @@
+package report
+
+import (
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// Timeline is the status history of one task, oldest transition first
+type Timeline []model.StatusTransition
+
+// Timelines groups status transitions by task. Transitions must be ordered
+// by time, as returned by the database.
+func Timelines(transitions []model.StatusTransition) map[int64]Timeline {
+	timelines := make(map[int64]Timeline)
+	for _, t := range transitions {
+		timelines[t.TaskID] = append(timelines[t.TaskID], t)
+	}
+	return timelines
+}
+
+// StatusAt returns the task's status at t, and false if it did not exist
+// yet or was deleted by then
+func (tl Timeline) StatusAt(t time.Time) (model.TaskStatus, bool) {
+	var status model.TaskStatus
+	for _, tr := range tl {
+		if tr.ChangedAt.After(t) {
+			break
+		}
+		status = tr.To
+	}
+	return status, status != ""
+}
+
+// Created returns when the task entered the board
+func (tl Timeline) Created() (time.Time, bool) {
+	if len(tl) == 0 {
+		return time.Time{}, false
+	}
+	return tl[0].ChangedAt, true
+}
+
+// FirstEntered returns the first time the task moved into status
+func (tl Timeline) FirstEntered(status model.TaskStatus) (time.Time, bool) {
+	for _, tr := range tl {
+		if tr.To == status {
+			return tr.ChangedAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// LastEntered returns the most recent time the task moved into status
+func (tl Timeline) LastEntered(status model.TaskStatus) (time.Time, bool) {
+	for i := len(tl) - 1; i >= 0; i-- {
+		if tl[i].To == status {
+			return tl[i].ChangedAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Days returns local midnights from start through end, inclusive
+func Days(start, end time.Time) []time.Time {
+	start = dayStart(start)
+	end = dayStart(end)
+	var days []time.Time
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days
+}
+
+// dayStart truncates t to local midnight
+func dayStart(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// dayEnd returns the last instant of t's local day, capped at now
+func dayEnd(t, now time.Time) time.Time {
+	end := dayStart(t).AddDate(0, 0, 1).Add(-time.Nanosecond)
+	if end.After(now) {
+		return now
+	}
+	return end
+}
@@ -0,0 +1,196 @@
+// Package taskfile converts tasks to and from the text edited in an
+// external editor: a front matter block with the fields, then the
+// description as Markdown.
+package taskfile
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/happytaoer/cli_kanban/internal/due"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// Front matter delimiter and the prefix of the comment WithProblem adds
+const (
+	delimiter     = "---"
+	problemPrefix = "# Error: "
+)
+
+// Format renders a task edit as front matter and a Markdown body
+func Format(edit model.TaskEdit) string {
+	var b strings.Builder
+	b.WriteString(delimiter + "\n")
+	b.WriteString("# status is todo, in_progress or done; tags are comma-separated;\n")
+	b.WriteString("# due is a date like 2026-11-01 14:00, fri or +3d, or empty.\n")
+	b.WriteString("# The description follows the second ---.\n")
+	fmt.Fprintf(&b, "title: %s\n", edit.Title)
+	fmt.Fprintf(&b, "status: %s\n", edit.Status)
+	fmt.Fprintf(&b, "tags: %s\n", strings.Join(edit.Tags, ", "))
+	dueText := ""
+	if edit.Due != nil {
+		dueText = due.Format(*edit.Due)
+	}
+	fmt.Fprintf(&b, "due: %s\n", dueText)
+	b.WriteString(delimiter + "\n\n")
+	if edit.Description != "" {
+		b.WriteString(edit.Description)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Parse reads text written by Format back into a task edit. Comment lines
+// starting with # in the front matter are ignored.
+func Parse(text string) (model.TaskEdit, error) {
+	var edit model.TaskEdit
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != delimiter {
+		return edit, fmt.Errorf("line 1: expected %s to start the front matter", delimiter)
+	}
+
+	seen := make(map[string]bool)
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == delimiter {
+			end = i
+			break
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return edit, fmt.Errorf("line %d: expected \"field: value\", got %q", i+1, line)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if seen[key] {
+			return edit, fmt.Errorf("line %d: %s is set twice", i+1, key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "title":
+			edit.Title = value
+		case "status":
+			status, err := model.ParseStatus(value)
+			if err != nil {
+				return edit, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			edit.Status = status
+		case "tags":
+			edit.Tags = parseTags(value)
+		case "due":
+			date, err := due.Parse(value, time.Now())
+			if err != nil {
+				return edit, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			edit.Due = date
+		default:
+			return edit, fmt.Errorf("line %d: unknown field %q (use title, status, tags, due)", i+1, key)
+		}
+	}
+
+	if end < 0 {
+		return edit, fmt.Errorf("the front matter is not closed with %s", delimiter)
+	}
+	if edit.Title == "" {
+		return edit, fmt.Errorf("title must not be empty")
+	}
+	if !seen["status"] {
+		return edit, fmt.Errorf("status is missing")
+	}
+
+	body := strings.Join(lines[end+1:], "\n")
+	edit.Description = strings.TrimRight(strings.TrimLeft(body, "\n"), " \t\n")
+	return edit, nil
+}
+
+// Normalize returns edit as Parse reads it back from Format, so it can be
+// compared with an edited file without whitespace-only differences
+func Normalize(edit model.TaskEdit) model.TaskEdit {
+	if parsed, err := Parse(Format(edit)); err == nil {
+		return parsed
+	}
+	return edit
+}
+
+// WithProblem puts an error comment at the top of the front matter of text
+// that failed to parse, replacing the comment of an earlier attempt. Lines
+// after the front matter are part of the description and kept as written.
+func WithProblem(text string, problem error) string {
+	lines := strings.Split(text, "\n")
+	kept := make([]string, 0, len(lines)+1)
+	inFrontMatter := true
+	for i, line := range lines {
+		if i > 0 && inFrontMatter {
+			if strings.TrimSpace(line) == delimiter {
+				inFrontMatter = false
+			} else if strings.HasPrefix(line, problemPrefix) {
+				continue
+			}
+		}
+		kept = append(kept, line)
+		if i == 0 && strings.TrimSpace(line) == delimiter {
+			kept = append(kept, problemPrefix+problem.Error())
+		}
+	}
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != delimiter {
+		// No front matter to put it in: start one so the error shows
+		return delimiter + "\n" + problemPrefix + problem.Error() + "\n" + text
+	}
+	return strings.Join(kept, "\n")
+}
+
+// parseTags splits a comma-separated tag list, normalized like stored tags
+func parseTags(s string) []string {
+	tags := []string{}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		tag := strings.TrimSpace(strings.ToLower(part))
+		if tag != "" && !seen[tag] {
+			tags = append(tags, tag)
+			seen[tag] = true
+		}
+	}
+	return tags
+}
+
+// WriteTemp writes text to a new temporary Markdown file and returns its path
+func WriteTemp(text string) (string, error) {
+	f, err := os.CreateTemp("", "cli_kanban-task-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// EditorCommand returns the command that opens path in $VISUAL or $EDITOR,
+// falling back to vi. The variables may include arguments, e.g. "code -w".
+func EditorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if strings.TrimSpace(editor) == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], path)...)
+}
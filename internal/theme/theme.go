@@ -0,0 +1,241 @@
+// Package theme defines the color palettes the board can be drawn with.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// Auto picks dark or light from the terminal background, or monochrome when
+// NO_COLOR is set
+const Auto = "auto"
+
+// Theme is a named color palette
+type Theme struct {
+	Name string
+
+	Primary   lipgloss.TerminalColor // titles, selected card background
+	Secondary lipgloss.TerminalColor // column titles, info lines
+	Success   lipgloss.TerminalColor
+	Danger    lipgloss.TerminalColor
+	Warning   lipgloss.TerminalColor
+	Muted     lipgloss.TerminalColor // hints and secondary text
+	Border    lipgloss.TerminalColor
+	Text      lipgloss.TerminalColor // card details such as the due date
+	OnPrimary lipgloss.TerminalColor // text on Primary and on flashes
+
+	HighlightText       lipgloss.TerminalColor // search matches
+	HighlightBackground lipgloss.TerminalColor
+
+	Tags    []lipgloss.TerminalColor // tag chip backgrounds, picked by tag hash
+	TagText lipgloss.TerminalColor
+
+	// Columns colors each column's title and border
+	Columns map[model.TaskStatus]lipgloss.TerminalColor
+
+	// Markdown is the glamour style descriptions are rendered with:
+	// "dark", "light" or "notty"
+	Markdown string
+
+	// Mono draws with reverse video and underline instead of colors
+	Mono bool
+}
+
+func hex(colors ...string) []lipgloss.TerminalColor {
+	out := make([]lipgloss.TerminalColor, len(colors))
+	for i, c := range colors {
+		out[i] = lipgloss.Color(c)
+	}
+	return out
+}
+
+func columns(todo, inProgress, done string) map[model.TaskStatus]lipgloss.TerminalColor {
+	return map[model.TaskStatus]lipgloss.TerminalColor{
+		model.StatusTodo:       lipgloss.Color(todo),
+		model.StatusInProgress: lipgloss.Color(inProgress),
+		model.StatusDone:       lipgloss.Color(done),
+	}
+}
+
+// builtin returns the built-in themes by name
+func builtin() map[string]Theme {
+	none := lipgloss.NoColor{}
+	return map[string]Theme{
+		"dark": {
+			Primary:             lipgloss.Color("#7C3AED"),
+			Secondary:           lipgloss.Color("#A78BFA"),
+			Success:             lipgloss.Color("#10B981"),
+			Danger:              lipgloss.Color("#EF4444"),
+			Warning:             lipgloss.Color("#F59E0B"),
+			Muted:               lipgloss.Color("#6B7280"),
+			Border:              lipgloss.Color("#374151"),
+			Text:                lipgloss.Color("#FFFFFF"),
+			OnPrimary:           lipgloss.Color("#FFFFFF"),
+			HighlightText:       lipgloss.Color("#111827"),
+			HighlightBackground: lipgloss.Color("#FDE047"),
+			Tags:                hex("#EF4444", "#F59E0B", "#10B981", "#3B82F6", "#8B5CF6", "#EC4899"),
+			TagText:             lipgloss.Color("#FFFFFF"),
+			Columns:             columns("#6B7280", "#3B82F6", "#10B981"),
+			Markdown:            "dark",
+		},
+		"light": {
+			Primary:             lipgloss.Color("#6D28D9"),
+			Secondary:           lipgloss.Color("#7C3AED"),
+			Success:             lipgloss.Color("#047857"),
+			Danger:              lipgloss.Color("#B91C1C"),
+			Warning:             lipgloss.Color("#B45309"),
+			Muted:               lipgloss.Color("#6B7280"),
+			Border:              lipgloss.Color("#D1D5DB"),
+			Text:                lipgloss.Color("#111827"),
+			OnPrimary:           lipgloss.Color("#FFFFFF"),
+			HighlightText:       lipgloss.Color("#111827"),
+			HighlightBackground: lipgloss.Color("#FDE047"),
+			Tags:                hex("#B91C1C", "#B45309", "#047857", "#1D4ED8", "#6D28D9", "#BE185D"),
+			TagText:             lipgloss.Color("#FFFFFF"),
+			Columns:             columns("#4B5563", "#1D4ED8", "#047857"),
+			Markdown:            "light",
+		},
+		"high-contrast": {
+			Primary:             lipgloss.Color("#FFD700"),
+			Secondary:           lipgloss.Color("#FFFFFF"),
+			Success:             lipgloss.Color("#00FF00"),
+			Danger:              lipgloss.Color("#FF4040"),
+			Warning:             lipgloss.Color("#FFFF00"),
+			Muted:               lipgloss.Color("#D0D0D0"),
+			Border:              lipgloss.Color("#FFFFFF"),
+			Text:                lipgloss.Color("#FFFFFF"),
+			OnPrimary:           lipgloss.Color("#000000"),
+			HighlightText:       lipgloss.Color("#000000"),
+			HighlightBackground: lipgloss.Color("#00FFFF"),
+			Tags:                hex("#FF4040", "#FFFF00", "#00FF00", "#00FFFF", "#FF80FF", "#FFFFFF"),
+			TagText:             lipgloss.Color("#000000"),
+			Columns:             columns("#FFFFFF", "#00FFFF", "#00FF00"),
+			Markdown:            "dark",
+		},
+		"solarized": {
+			Primary:             lipgloss.Color("#6C71C4"),
+			Secondary:           lipgloss.Color("#2AA198"),
+			Success:             lipgloss.Color("#859900"),
+			Danger:              lipgloss.Color("#DC322F"),
+			Warning:             lipgloss.Color("#B58900"),
+			Muted:               lipgloss.Color("#586E75"),
+			Border:              lipgloss.Color("#586E75"),
+			Text:                lipgloss.Color("#93A1A1"),
+			OnPrimary:           lipgloss.Color("#FDF6E3"),
+			HighlightText:       lipgloss.Color("#002B36"),
+			HighlightBackground: lipgloss.Color("#B58900"),
+			Tags:                hex("#DC322F", "#CB4B16", "#859900", "#268BD2", "#6C71C4", "#D33682"),
+			TagText:             lipgloss.Color("#FDF6E3"),
+			Columns:             columns("#839496", "#268BD2", "#859900"),
+			Markdown:            "dark",
+		},
+		"monochrome": {
+			Primary:             none,
+			Secondary:           none,
+			Success:             none,
+			Danger:              none,
+			Warning:             none,
+			Muted:               none,
+			Border:              none,
+			Text:                none,
+			OnPrimary:           none,
+			HighlightText:       none,
+			HighlightBackground: none,
+			Tags:                []lipgloss.TerminalColor{none},
+			TagText:             none,
+			Columns: map[model.TaskStatus]lipgloss.TerminalColor{
+				model.StatusTodo: none, model.StatusInProgress: none, model.StatusDone: none,
+			},
+			Markdown: "notty",
+			Mono:     true,
+		},
+	}
+}
+
+// Names lists the theme names accepted by Resolve
+func Names() []string {
+	names := []string{Auto}
+	var themes []string
+	for name := range builtin() {
+		themes = append(themes, name)
+	}
+	sort.Strings(themes)
+	return append(names, themes...)
+}
+
+// Validate checks a theme name and column color overrides
+func Validate(name string, columnColors map[string]string) error {
+	if name != "" && name != Auto {
+		if _, ok := builtin()[name]; !ok {
+			return fmt.Errorf("unknown theme %q (use %s)", name, strings.Join(Names(), ", "))
+		}
+	}
+	for status, color := range columnColors {
+		if _, err := model.ParseStatus(status); err != nil {
+			return fmt.Errorf("column color: %w", err)
+		}
+		if !validColor(color) {
+			return fmt.Errorf("column color for %s: %q is not a #RRGGBB color or ANSI color number", status, color)
+		}
+	}
+	return nil
+}
+
+// validColor accepts #RGB, #RRGGBB and ANSI color numbers 0-255
+func validColor(c string) bool {
+	if strings.HasPrefix(c, "#") {
+		if len(c) != 4 && len(c) != 7 {
+			return false
+		}
+		for _, r := range c[1:] {
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+		return true
+	}
+	n := 0
+	for _, r := range c {
+		if r < '0' || r > '9' {
+			return false
+		}
+		n = n*10 + int(r-'0')
+	}
+	return c != "" && n <= 255
+}
+
+// Resolve returns the named theme with column color overrides applied. Auto
+// (or "") chooses monochrome when NO_COLOR is set, otherwise dark or light
+// from the terminal background as detected by termenv. Unknown names fall
+// back to dark; see Validate.
+func Resolve(name string, columnColors map[string]string) Theme {
+	if name == "" || name == Auto {
+		switch {
+		case os.Getenv("NO_COLOR") != "":
+			name = "monochrome"
+		case lipgloss.HasDarkBackground():
+			name = "dark"
+		default:
+			name = "light"
+		}
+	}
+
+	themes := builtin()
+	t, ok := themes[name]
+	if !ok {
+		name, t = "dark", themes["dark"]
+	}
+	t.Name = name
+
+	if !t.Mono {
+		for status, color := range columnColors {
+			t.Columns[model.TaskStatus(status)] = lipgloss.Color(color)
+		}
+	}
+	return t
+}
@@ -0,0 +1,469 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/happytaoer/cli_kanban/internal/due"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// action is a board command that can be run from the command palette. Keys
+// on the board call the same methods.
+type action struct {
+	name     string // palette name, e.g. "move to done"
+	binding  string // key binding shown as a hint, "" if the action has none
+	arg      string // argument prompt; "" when the action takes no argument
+	needTask bool   // the action works on the selected task
+	run      func(m Model, task *model.Task, arg string) (Model, tea.Cmd)
+	preview  func(m Model, arg string) string // shown under the argument prompt, optional
+}
+
+type taskArchivedMsg struct{}
+
+// actions returns every palette action
+func (m Model) actions() []action {
+	actions := []action{
+		{name: "add task", binding: "add", arg: "Title", run: func(m Model, _ *model.Task, arg string) (Model, tea.Cmd) {
+			return m, m.createTask(arg, m.columns[m.currentColumn].Status)
+		}},
+		{name: "rename task", binding: "edit", arg: "Title", needTask: true, run: func(m Model, task *model.Task, arg string) (Model, tea.Cmd) {
+			return m, m.updateTask(task.ID, arg, task.Status)
+		}},
+		{name: "edit description", binding: "description", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.startEditDescription()
+		}},
+		{name: "edit in editor", binding: "editor", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.openEditor()
+		}},
+		{name: "edit tags", binding: "tags", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.startEditTags()
+		}},
+		{name: "add tag", arg: "Tag", needTask: true, run: func(m Model, task *model.Task, arg string) (Model, tea.Cmd) {
+			if m.hasSelection() {
+				return m, m.updateSelectedTags(parseTagsInput(arg), nil)
+			}
+			tags := append(append([]string{}, task.Tags...), parseTagsInput(arg)...)
+			return m, m.updateTags(task.ID, parseTagsInput(strings.Join(tags, ",")))
+		}},
+		{name: "remove tag", arg: "Tag", needTask: true, run: func(m Model, task *model.Task, arg string) (Model, tea.Cmd) {
+			if m.hasSelection() {
+				return m, m.updateSelectedTags(nil, parseTagsInput(arg))
+			}
+			remove := make(map[string]bool)
+			for _, t := range parseTagsInput(arg) {
+				remove[t] = true
+			}
+			var tags []string
+			for _, t := range task.Tags {
+				if !remove[t] {
+					tags = append(tags, t)
+				}
+			}
+			return m, m.updateTags(task.ID, tags)
+		}},
+		{name: "set due", binding: "due", arg: "Date (e.g. fri, +3d, in 2 weeks, 2026-11-01 14:00 or none)", needTask: true, preview: previewDue, run: func(m Model, task *model.Task, arg string) (Model, tea.Cmd) {
+			date, err := due.Parse(arg, m.currentTime)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			if m.hasSelection() {
+				return m, m.updateSelectedDue(date)
+			}
+			return m, m.updateDue(task.ID, date)
+		}},
+		{name: "clear due", needTask: true, run: func(m Model, task *model.Task, _ string) (Model, tea.Cmd) {
+			if m.hasSelection() {
+				return m, m.updateSelectedDue(nil)
+			}
+			return m, m.updateDue(task.ID, nil)
+		}},
+		{name: "set estimate", binding: "estimate", arg: "Estimate (a number or none)", needTask: true, run: func(m Model, task *model.Task, arg string) (Model, tea.Cmd) {
+			var estimate *float64
+			if arg != "none" {
+				v, err := strconv.ParseFloat(arg, 64)
+				if err != nil || v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+					m.err = fmt.Errorf("invalid estimate, use a non-negative finite number")
+					return m, nil
+				}
+				estimate = &v
+			}
+			if m.hasSelection() {
+				return m, m.updateSelectedEstimate(estimate)
+			}
+			return m, m.updateEstimate(task.ID, estimate)
+		}},
+		{name: "move to next column", binding: "move", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.moveToNextColumn()
+		}},
+	}
+
+	for i, col := range m.columns {
+		column := i
+		actions = append(actions, action{
+			name:     "move to " + strings.ToLower(col.Name),
+			needTask: true,
+			run: func(m Model, task *model.Task, _ string) (Model, tea.Cmd) {
+				if m.hasSelection() {
+					return m.moveSelected(column)
+				}
+				return m.moveToColumn(task, column)
+			},
+		})
+	}
+
+	return append(actions, []action{
+		{name: "start/stop timer", binding: "timer", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.toggleTimer()
+		}},
+		{name: "focus on task", binding: "focus", needTask: true, run: func(m Model, task *model.Task, _ string) (Model, tea.Cmd) {
+			return m.enterFocus(task), nil
+		}},
+		{name: "delete task", binding: "delete", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.confirmDeleteTask()
+		}},
+		{name: "archive task", binding: "archive", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.archiveSelectedTask()
+		}},
+		{name: "select task", binding: "select", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.toggleSelect()
+		}},
+		{name: "select range", binding: "select_range", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.selectRange()
+		}},
+		{name: "select column", binding: "select_column", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.selectColumn()
+		}},
+		{name: "select all matching", binding: "select_all", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.selectMatching()
+		}},
+		{name: "clear selection", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.clearSelection(), nil
+		}},
+		{name: "archive done", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.archiveDoneTasks()
+		}},
+		{name: "unarchive task", arg: "Task ID", run: func(m Model, _ *model.Task, arg string) (Model, tea.Cmd) {
+			id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+			if err != nil {
+				m.err = fmt.Errorf("invalid task ID: %s", arg)
+				return m, nil
+			}
+			return m, m.unarchiveTask(id)
+		}},
+		{name: "search", binding: "search", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.openSearch(), nil
+		}},
+		{name: "show overdue", binding: "filter_overdue", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.toggleDueFilter(overdueFilter)
+		}},
+		{name: "show due today", binding: "filter_due_today", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.toggleDueFilter(dueTodayFilter)
+		}},
+		{name: "clear filter", binding: "clear_filter", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.applyView(nil)
+		}},
+		{name: "find task", binding: "find", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.openFinder(), nil
+		}},
+		{name: "saved views", binding: "views", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.openViewPicker()
+		}},
+		{name: "apply view", arg: "View name", run: func(m Model, _ *model.Task, arg string) (Model, tea.Cmd) {
+			for i := range m.views {
+				if strings.EqualFold(m.views[i].Name, arg) {
+					return m.applyView(&m.views[i])
+				}
+			}
+			m.err = fmt.Errorf("no saved view named %q", arg)
+			return m, nil
+		}},
+		{name: "save view", arg: "View name", run: func(m Model, _ *model.Task, arg string) (Model, tea.Cmd) {
+			return m, m.saveView(m.currentViewAs(arg))
+		}},
+		{name: "switch sprint", binding: "sprints", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.openSprintSwitcher(), m.loadSprints()
+		}},
+		{name: "show calendar", binding: "calendar", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.openCalendar()
+		}},
+		{name: "show stats", binding: "stats", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.openStats()
+		}},
+		{name: "show details", binding: "detail", needTask: true, run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.openDetail()
+		}},
+		{name: "toggle detail pane", binding: "detail_pane", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.toggleDetailPane()
+		}},
+		{name: "refresh", binding: "refresh", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m.refresh()
+		}},
+		{name: "help", binding: "help", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			m.viewMode = ViewModeHelp
+			return m, nil
+		}},
+		{name: "quit", binding: "quit", run: func(m Model, _ *model.Task, _ string) (Model, tea.Cmd) {
+			return m, tea.Quit
+		}},
+	}...)
+}
+
+// startAddTask opens the add task input for the current column
+func (m Model) startAddTask() (Model, tea.Cmd) {
+	m.viewMode = ViewModeAddTask
+	m.textInput.SetValue("")
+	m.textInput.Focus()
+	return m, nil
+}
+
+// startEditTitle opens the title input for the selected task
+func (m Model) startEditTitle() (Model, tea.Cmd) {
+	task := m.getCurrentTask()
+	if task != nil {
+		m.viewMode = ViewModeEditTask
+		m.textInput.SetValue(task.Title)
+		m.textInput.Focus()
+	}
+	return m, nil
+}
+
+// startEditDescription opens the description editor for the selected task
+func (m Model) startEditDescription() (Model, tea.Cmd) {
+	task := m.getCurrentTask()
+	if task != nil {
+		m.viewMode = ViewModeEditDescription
+		// Expand textarea to fit available width when editing description
+		textareaWidth := m.width - 4
+		if textareaWidth < 40 {
+			textareaWidth = 40
+		}
+		m.textArea.SetWidth(textareaWidth)
+		// Expand textarea height based on available screen height
+		availableHeight := m.height - 8 // title, info, spacing
+		if availableHeight < 6 {
+			availableHeight = 6
+		}
+		m.textArea.SetHeight(availableHeight)
+		m.textArea.SetValue(task.Description)
+		m.textArea.Focus()
+	}
+	return m, nil
+}
+
+// startEditTags opens the tags input for the task under the cursor, or an
+// empty one listing tag changes for the selected tasks
+func (m Model) startEditTags() (Model, tea.Cmd) {
+	if m.hasSelection() {
+		m.viewMode = ViewModeEditTags
+		m.textInput.SetValue("")
+		m.textInput.Focus()
+		return m, nil
+	}
+	task := m.getCurrentTask()
+	if task != nil {
+		m.viewMode = ViewModeEditTags
+		m.textInput.SetValue(strings.Join(task.Tags, ", "))
+		m.textInput.Focus()
+	}
+	return m, nil
+}
+
+// startEditDue opens the due date input for the task under the cursor, or
+// an empty one for the selected tasks
+func (m Model) startEditDue() (Model, tea.Cmd) {
+	if m.hasSelection() {
+		m.viewMode = ViewModeEditDue
+		m.dueInput.SetValue("")
+		m.dueInput.Focus()
+		return m, nil
+	}
+	task := m.getCurrentTask()
+	if task != nil {
+		m.viewMode = ViewModeEditDue
+		if task.Due != nil {
+			m.dueInput.SetValue(due.Format(*task.Due))
+		} else {
+			m.dueInput.SetValue("")
+		}
+		m.dueInput.Focus()
+	}
+	return m, nil
+}
+
+// startEditEstimate opens the estimate input for the selected tasks, or the
+// task under the cursor
+func (m Model) startEditEstimate() (Model, tea.Cmd) {
+	if m.hasSelection() {
+		m.viewMode = ViewModeEditEstimate
+		m.textInput.SetValue("")
+		m.textInput.Focus()
+		return m, nil
+	}
+	task := m.getCurrentTask()
+	if task != nil {
+		m.viewMode = ViewModeEditEstimate
+		if task.Estimate != nil {
+			m.textInput.SetValue(strconv.FormatFloat(*task.Estimate, 'f', -1, 64))
+		} else {
+			m.textInput.SetValue("")
+		}
+		m.textInput.Focus()
+	}
+	return m, nil
+}
+
+// confirmDeleteTask asks to confirm deleting the selected tasks, or the
+// task under the cursor
+func (m Model) confirmDeleteTask() (Model, tea.Cmd) {
+	if m.hasSelection() {
+		m.pendingDeleteIDs = m.selectedIDs()
+		m.viewMode = ViewModeConfirmDelete
+		return m, nil
+	}
+	task := m.getCurrentTask()
+	if task != nil {
+		m.pendingDeleteIDs = []int64{task.ID}
+		m.viewMode = ViewModeConfirmDelete
+	}
+	return m, nil
+}
+
+// moveToNextColumn moves the task under the cursor one column to the
+// right, wrapping around to the first. Selected tasks all move to the column
+// right of the current one.
+func (m Model) moveToNextColumn() (Model, tea.Cmd) {
+	if m.hasSelection() {
+		return m.moveSelected((m.currentColumn + 1) % len(m.columns))
+	}
+	task := m.getCurrentTask()
+	if task == nil {
+		return m, nil
+	}
+	return m.moveToColumn(task, (m.currentColumn+1)%len(m.columns))
+}
+
+// moveToColumn moves a task to a column, keeping it selected
+func (m Model) moveToColumn(task *model.Task, column int) (Model, tea.Cmd) {
+	if task.Status == m.columns[column].Status {
+		return m, nil
+	}
+	// Follow the task unless the active view hides its new column
+	if m.columnShown(column) {
+		m.currentColumn = column
+		m.followTaskID = task.ID
+	}
+	return m, m.moveTask(task, column)
+}
+
+// toggleTimer starts the timer on the selected task, or asks for a note
+// before stopping a running one
+func (m Model) toggleTimer() (Model, tea.Cmd) {
+	task := m.getCurrentTask()
+	if task == nil {
+		return m, nil
+	}
+	if task.TimerStart != nil {
+		// Ask for an optional note before stopping the timer
+		m.viewMode = ViewModeTimerNote
+		m.textInput.SetValue("")
+		m.textInput.Focus()
+		return m, nil
+	}
+	return m, m.startTimer(task.ID)
+}
+
+// openStats shows the stats charts
+func (m Model) openStats() (Model, tea.Cmd) {
+	m.viewMode = ViewModeStats
+	m.stats = statsData{}
+	return m, m.loadStats()
+}
+
+// openViewPicker shows the saved view picker with the active view selected
+func (m Model) openViewPicker() (Model, tea.Cmd) {
+	m.viewMode = ViewModeViews
+	m.viewCursor = 0
+	for i, v := range m.views {
+		if m.activeView != nil && m.activeView.ID == v.ID {
+			m.viewCursor = i + 1
+		}
+	}
+	return m, m.loadViews()
+}
+
+// openSearch opens the search input with the current filter
+func (m Model) openSearch() Model {
+	m.viewMode = ViewModeSearch
+	m.prevSearchQuery = m.searchQuery
+	m.searchInput.SetValue(m.searchQuery)
+	m.searchInput.CursorEnd()
+	m.searchInput.Focus()
+	return m
+}
+
+// refresh reloads tasks, sprints and views from the database
+func (m Model) refresh() (Model, tea.Cmd) {
+	return m, tea.Batch(m.loadTasks(), m.loadSprints(), m.loadViews())
+}
+
+// archiveSelectedTask archives the selected tasks, or the task under the
+// cursor
+func (m Model) archiveSelectedTask() (Model, tea.Cmd) {
+	if m.hasSelection() {
+		return m, m.archiveSelected()
+	}
+	task := m.getCurrentTask()
+	if task == nil {
+		return m, nil
+	}
+	return m, m.archiveTask(task.ID)
+}
+
+// archiveTask moves a task off the board
+func (m Model) archiveTask(id int64) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.ArchiveTask(id); err != nil {
+			return errMsg{err}
+		}
+		return taskArchivedMsg{}
+	}
+}
+
+// archiveDoneTasks archives the tasks the Done column shows; tasks the
+// filter or sprint scope hides stay on the board
+func (m Model) archiveDoneTasks() (Model, tea.Cmd) {
+	var ids []int64
+	for i, col := range m.columns {
+		if col.Status != model.StatusDone || !m.columnShown(i) {
+			continue
+		}
+		for _, idx := range m.visibleTaskIndices(i) {
+			ids = append(ids, col.Tasks[idx].ID)
+		}
+	}
+	if len(ids) == 0 {
+		m.err = fmt.Errorf("no done tasks shown to archive")
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		if err := m.db.ArchiveTasks(ids); err != nil {
+			return errMsg{err}
+		}
+		return taskArchivedMsg{}
+	}
+}
+
+// unarchiveTask puts an archived task back on the board
+func (m Model) unarchiveTask(id int64) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.UnarchiveTask(id); err != nil {
+			return errMsg{err}
+		}
+		return taskArchivedMsg{}
+	}
+}
@@ -0,0 +1,431 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/config"
+	"github.com/happytaoer/cli_kanban/internal/due"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// Smallest calendar cell, inside its border
+const (
+	minCalendarCellWidth  = 8
+	minCalendarCellHeight = 2
+)
+
+// calendarState is the month or week shown in the calendar view
+type calendarState struct {
+	cursor     time.Time // highlighted day, at midnight
+	week       bool      // show the cursor's week instead of its month
+	dayList    bool      // listing the tasks due on the cursor day
+	listCursor int
+	moving     int64 // task being rescheduled, 0 when none
+}
+
+// openCalendar shows the calendar on the month of today
+func (m Model) openCalendar() (Model, tea.Cmd) {
+	m.calendar = calendarState{cursor: startOfDay(m.currentTime), week: m.calendar.week}
+	m.viewMode = ViewModeCalendar
+	return m, nil
+}
+
+// startOfDay returns midnight of t's day in local time
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// calendarTasks groups the tasks the filter and view show by due day,
+// earliest due time first
+func (m Model) calendarTasks() map[time.Time][]model.Task {
+	days := make(map[time.Time][]model.Task)
+	for i, col := range m.columns {
+		if !m.columnShown(i) {
+			continue
+		}
+		for _, idx := range m.visibleTaskIndices(i) {
+			task := col.Tasks[idx]
+			if task.Due == nil {
+				continue
+			}
+			day := task.Due.Day(time.Local)
+			days[day] = append(days[day], task)
+		}
+	}
+	for _, tasks := range days {
+		sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].Due.Time.Before(tasks[j].Due.Time) })
+	}
+	return days
+}
+
+// handleCalendarKeys handles keyboard input in the calendar view
+func (m Model) handleCalendarKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.calendar.dayList {
+		return m.handleCalendarListKeys(msg)
+	}
+
+	key := msg.String()
+	switch m.keys.screen(config.ContextCalendar, key) {
+	case "calendar_week":
+		m.calendar.week = !m.calendar.week
+		return m, nil
+	case "calendar_today":
+		m.calendar.cursor = startOfDay(m.currentTime)
+		return m, nil
+	case "calendar_prev":
+		return m.shiftCalendar(-1), nil
+	case "calendar_next":
+		return m.shiftCalendar(1), nil
+	case "calendar_move":
+		// Pick up the only task of the day; with several, pick from the list
+		tasks := m.calendarTasks()[m.calendar.cursor]
+		switch {
+		case m.calendar.moving != 0 || len(tasks) == 0:
+		case len(tasks) == 1:
+			m.calendar.moving = tasks[0].ID
+		default:
+			m.calendar.dayList = true
+			m.calendar.listCursor = 0
+		}
+		return m, nil
+	}
+
+	switch m.keys.input(key) {
+	case "submit":
+		if m.calendar.moving != 0 {
+			return m.rescheduleTask()
+		}
+		if len(m.calendarTasks()[m.calendar.cursor]) > 0 {
+			m.calendar.dayList = true
+			m.calendar.listCursor = 0
+		}
+		return m, nil
+	case "cancel":
+		// Cancel a reschedule first, then leave
+		if m.calendar.moving != 0 {
+			m.calendar.moving = 0
+			return m, nil
+		}
+		m.viewMode = ViewModeBoard
+		return m, nil
+	}
+
+	switch m.keys.board(key) {
+	case "left":
+		m.calendar.cursor = m.calendar.cursor.AddDate(0, 0, -1)
+	case "right":
+		m.calendar.cursor = m.calendar.cursor.AddDate(0, 0, 1)
+	case "up":
+		m.calendar.cursor = m.calendar.cursor.AddDate(0, 0, -7)
+	case "down":
+		m.calendar.cursor = m.calendar.cursor.AddDate(0, 0, 7)
+	}
+	if m.keys.closes(key, "calendar") {
+		m.calendar.moving = 0
+		m.viewMode = ViewModeBoard
+	}
+	return m, nil
+}
+
+// handleCalendarListKeys handles keyboard input in the list of a day's tasks
+func (m Model) handleCalendarListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tasks := m.calendarTasks()[m.calendar.cursor]
+	if m.calendar.listCursor >= len(tasks) {
+		m.calendar.listCursor = len(tasks) - 1
+	}
+
+	key := msg.String()
+	if m.keys.screen(config.ContextCalendar, key) == "calendar_move" {
+		if m.calendar.listCursor >= 0 {
+			m.calendar.moving = tasks[m.calendar.listCursor].ID
+		}
+		m.calendar.dayList = false
+		return m, nil
+	}
+
+	switch m.keys.input(key) {
+	case "submit":
+		// Show the task on the board
+		if m.calendar.listCursor >= 0 {
+			m = m.jumpToTask(tasks[m.calendar.listCursor].ID)
+		}
+		m.calendar.dayList = false
+		m.viewMode = ViewModeBoard
+		return m, nil
+	case "cancel":
+		m.calendar.dayList = false
+		return m, nil
+	}
+
+	switch m.keys.board(key) {
+	case "up":
+		if m.calendar.listCursor > 0 {
+			m.calendar.listCursor--
+		}
+	case "down":
+		if m.calendar.listCursor < len(tasks)-1 {
+			m.calendar.listCursor++
+		}
+	}
+	if m.keys.closes(key, "calendar") {
+		m.calendar.dayList = false
+	}
+	return m, nil
+}
+
+// shiftCalendar moves the cursor by months, or by weeks in the week layout.
+// The day of the month stops at the end of a shorter month.
+func (m Model) shiftCalendar(n int) Model {
+	if m.calendar.week {
+		m.calendar.cursor = m.calendar.cursor.AddDate(0, 0, 7*n)
+		return m
+	}
+	c := m.calendar.cursor
+	first := time.Date(c.Year(), c.Month()+time.Month(n), 1, 0, 0, 0, 0, time.Local)
+	day := c.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	m.calendar.cursor = first.AddDate(0, 0, day-1)
+	return m
+}
+
+// rescheduleTask moves the task being rescheduled to the cursor day,
+// keeping its time of day if it has one
+func (m Model) rescheduleTask() (Model, tea.Cmd) {
+	task := m.findTask(m.calendar.moving)
+	m.calendar.moving = 0
+	if task == nil || task.Due == nil {
+		return m, nil
+	}
+	day := m.calendar.cursor
+	date := model.Date(day.Year(), day.Month(), day.Day())
+	if !task.Due.AllDay {
+		old := task.Due.Time.Local()
+		date.Time = time.Date(day.Year(), day.Month(), day.Day(), old.Hour(), old.Minute(), 0, 0, time.Local)
+		date.AllDay = false
+	}
+	return m, m.updateDue(task.ID, &date)
+}
+
+// calendarDays returns the days the calendar shows: the cursor's week, or
+// the whole weeks (Monday to Sunday) covering the cursor's month
+func (m Model) calendarDays() []time.Time {
+	c := m.calendar.cursor
+	first, last := c, c
+	if !m.calendar.week {
+		first = time.Date(c.Year(), c.Month(), 1, 0, 0, 0, 0, time.Local)
+		last = first.AddDate(0, 1, -1)
+	}
+	first = first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+	last = last.AddDate(0, 0, (7-int(last.Weekday()))%7)
+
+	var days []time.Time
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+	return days
+}
+
+// viewCalendar renders the calendar view
+func (m Model) viewCalendar() string {
+	if m.calendar.dayList {
+		return m.viewCalendarDay()
+	}
+
+	var b strings.Builder
+	c := m.calendar.cursor
+	days := m.calendarDays()
+	heading := c.Format("January 2006")
+	if m.calendar.week {
+		heading = "Week of " + days[0].Format("Mon 2 Jan 2006")
+	}
+	b.WriteString(titleStyle.Render("📅 Calendar · " + heading))
+	b.WriteString("\n")
+
+	weeks := len(days) / 7
+	cellWidth := m.width/7 - 2
+	if cellWidth < minCalendarCellWidth {
+		cellWidth = minCalendarCellWidth
+	}
+	// Title, weekday names, the status line and the footer
+	cellHeight := (m.height-6)/weeks - 2
+	if cellHeight < minCalendarCellHeight {
+		cellHeight = minCalendarCellHeight
+	}
+
+	var names []string
+	for _, d := range days[:7] {
+		names = append(names, lipgloss.NewStyle().Width(cellWidth+2).Align(lipgloss.Center).
+			Foreground(colorSecondary).Bold(true).Render(d.Format("Mon")))
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, names...))
+	b.WriteString("\n")
+
+	byDay := m.calendarTasks()
+	for w := 0; w < weeks; w++ {
+		var cells []string
+		for _, d := range days[w*7 : w*7+7] {
+			cells = append(cells, m.renderCalendarCell(d, byDay[d], cellWidth, cellHeight))
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.calendarStatus(byDay[c]))
+	b.WriteString("\n")
+	period := "Month"
+	if m.calendar.week {
+		period = "Week"
+	}
+	help := m.keys.footer([]footerItem{
+		{[]string{"left", "up", "down", "right"}, "Day"},
+		{[]string{"calendar_prev", "calendar_next"}, period},
+		{[]string{"calendar_week"}, "Month/week"},
+		{[]string{"calendar_today"}, "Today"},
+		{[]string{"submit"}, "Tasks"},
+		{[]string{"calendar_move"}, "Reschedule"},
+		{[]string{"cancel"}, "Back"},
+	})
+	if m.calendar.moving != 0 {
+		help = m.keys.footer([]footerItem{
+			{[]string{"left", "up", "down", "right"}, "Pick a day"},
+			{[]string{"submit"}, "Move here"},
+			{[]string{"cancel"}, "Cancel"},
+		})
+	}
+	b.WriteString(helpStyle.Render(help))
+	return b.String()
+}
+
+// renderCalendarCell renders a day: its number, a count per column in the
+// column's color, then as many task titles as fit
+func (m Model) renderCalendarCell(day time.Time, tasks []model.Task, width, height int) string {
+	cursor := day.Equal(m.calendar.cursor)
+	style := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(colorBorder).
+		Width(width).
+		Height(height)
+	if cursor {
+		style = style.Border(lipgloss.ThickBorder()).BorderForeground(colorPrimary)
+	}
+
+	number := lipgloss.NewStyle()
+	switch {
+	case day.Equal(startOfDay(m.currentTime)):
+		number = number.Bold(true).Foreground(colorWarning)
+	case !m.calendar.week && day.Month() != m.calendar.cursor.Month():
+		number = number.Foreground(colorMuted)
+	}
+	label := fmt.Sprintf("%d", day.Day())
+	if day.Day() == 1 || m.calendar.week {
+		label = day.Format("2 Jan")
+	}
+	lines := []string{number.Render(label)}
+
+	if len(tasks) > 0 {
+		var counts []string
+		for _, col := range m.columns {
+			n := 0
+			for _, task := range tasks {
+				if task.Status == col.Status {
+					n++
+				}
+			}
+			if n > 0 {
+				counts = append(counts, lipgloss.NewStyle().Foreground(columnColor(col.Status)).Render(fmt.Sprintf("■%d", n)))
+			}
+		}
+		lines = append(lines, strings.Join(counts, " "))
+	}
+
+	for i, task := range tasks {
+		if len(lines) == height {
+			break
+		}
+		if len(lines) == height-1 && i < len(tasks)-1 {
+			lines = append(lines, lipgloss.NewStyle().Foreground(colorMuted).Render(fmt.Sprintf("+%d more", len(tasks)-i)))
+			break
+		}
+		title := task.Title
+		if task.ID == m.calendar.moving {
+			title = "→ " + title
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(columnColor(task.Status)).Render(truncate(title, width)))
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// calendarStatus describes the cursor day, or where the task being
+// rescheduled goes
+func (m Model) calendarStatus(tasks []model.Task) string {
+	day := due.Describe(model.Date(m.calendar.cursor.Date()), m.currentTime)
+	if m.calendar.moving != 0 {
+		if task := m.findTask(m.calendar.moving); task != nil {
+			return lipgloss.NewStyle().Bold(true).Foreground(colorPrimary).Render(
+				fmt.Sprintf("Moving %q to %s", task.Title, day))
+		}
+	}
+	text := fmt.Sprintf("%s · %d tasks due", day, len(tasks))
+	if len(tasks) == 1 {
+		text = fmt.Sprintf("%s · 1 task due", day)
+	}
+	return lipgloss.NewStyle().Foreground(colorMuted).Render(text)
+}
+
+// viewCalendarDay renders the list of tasks due on the cursor day
+func (m Model) viewCalendarDay() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("📅 " + m.calendar.cursor.Format("Monday 2 January 2006")))
+	b.WriteString("\n\n")
+
+	// Pad column names so the titles line up
+	nameWidth := 0
+	for _, col := range m.columns {
+		if w := lipgloss.Width(col.Name); w > nameWidth {
+			nameWidth = w
+		}
+	}
+	width := m.width - nameWidth - 13 // marker, time, status and spacing
+	if width < 10 {
+		width = 10
+	}
+	for i, task := range m.calendarTasks()[m.calendar.cursor] {
+		clock := "     "
+		if !task.Due.AllDay {
+			clock = task.Due.Time.Local().Format("15:04")
+		}
+		name := string(task.Status)
+		for _, col := range m.columns {
+			if col.Status == task.Status {
+				name = col.Name
+			}
+		}
+		status := lipgloss.NewStyle().Foreground(columnColor(task.Status)).Width(nameWidth + 2).Render("■ " + name)
+		title := truncate(task.Title, width)
+		if i == m.calendar.listCursor {
+			title = taskActiveStyle.Copy().Padding(0).MarginBottom(0).Width(0).Render(title)
+			b.WriteString("▸ ")
+		} else {
+			b.WriteString("  ")
+		}
+		b.WriteString(clock + "  " + status + "  " + title)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render(m.keys.footer([]footerItem{
+		{[]string{"up", "down"}, "Select"},
+		{[]string{"submit"}, "Show on board"},
+		{[]string{"calendar_move"}, "Reschedule"},
+		{[]string{"cancel"}, "Back"},
+	})))
+	return b.String()
+}
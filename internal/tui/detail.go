@@ -0,0 +1,354 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/config"
+	"github.com/happytaoer/cli_kanban/internal/due"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// Detail pane width: a third of the terminal, within these bounds
+const (
+	minDetailPaneWidth = 32
+	maxDetailPaneWidth = 60
+)
+
+// detailState is the task shown in the full-screen detail view
+type detailState struct {
+	taskID int64
+	notes  []model.TimeEntry // the task's time entries that have a note
+	scroll int               // first content line shown
+}
+
+type detailLoadedMsg struct {
+	taskID  int64
+	entries []model.TimeEntry
+}
+
+// linkPattern matches URLs in a description
+var linkPattern = regexp.MustCompile(`https?://[^\s<>()\[\]"']+`)
+
+// openDetail shows the selected task in the full-screen detail view
+func (m Model) openDetail() (Model, tea.Cmd) {
+	task := m.getCurrentTask()
+	if task == nil {
+		return m, nil
+	}
+	m.detail = detailState{taskID: task.ID}
+	m.viewMode = ViewModeDetail
+	return m, m.loadDetail(task.ID)
+}
+
+// toggleDetailPane shows or hides the detail pane beside the board
+func (m Model) toggleDetailPane() (Model, tea.Cmd) {
+	m.showDetailPane = !m.showDetailPane
+	m.reflow()
+	return m, nil
+}
+
+// loadDetail loads the timer notes of the task in the detail view
+func (m Model) loadDetail(taskID int64) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := m.db.GetTaskTimeEntries(taskID)
+		if err != nil {
+			return errMsg{err}
+		}
+		return detailLoadedMsg{taskID: taskID, entries: entries}
+	}
+}
+
+// handleDetailKeys handles keyboard input in the detail view
+func (m Model) handleDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	page := m.detailPageHeight()
+	key := msg.String()
+	switch m.keys.screen(config.ContextDetail, key) {
+	case "page_up":
+		return m.scrollDetail(-page), nil
+	case "page_down":
+		return m.scrollDetail(page), nil
+	case "top":
+		return m.scrollDetail(-m.detail.scroll), nil
+	case "bottom":
+		return m.scrollDetail(len(m.detailLines())), nil
+	}
+
+	if m.keys.closes(key, "detail") {
+		m.viewMode = ViewModeBoard
+		return m, nil
+	}
+	switch m.keys.board(key) {
+	case "up":
+		return m.scrollDetail(-1), nil
+	case "down":
+		return m.scrollDetail(1), nil
+	case "description":
+		// Editing starts from the board selection, which is this task
+		m.viewMode = ViewModeBoard
+		return m.startEditDescription()
+	}
+	return m, nil
+}
+
+// scrollDetail scrolls the detail view by delta lines
+func (m Model) scrollDetail(delta int) Model {
+	maxScroll := len(m.detailLines()) - m.detailPageHeight()
+	m.detail.scroll += delta
+	if m.detail.scroll > maxScroll {
+		m.detail.scroll = maxScroll
+	}
+	if m.detail.scroll < 0 {
+		m.detail.scroll = 0
+	}
+	return m
+}
+
+// detailPageHeight returns how many content lines the detail view shows
+func (m Model) detailPageHeight() int {
+	height := m.height - 4 // title and footer with their spacing
+	if height < 1 {
+		height = 1
+	}
+	return height
+}
+
+// detailWidth returns the text width of the full-screen detail view
+func (m Model) detailWidth() int {
+	width := m.width - 4
+	if width > 100 {
+		width = 100
+	}
+	if width < 20 {
+		width = 20
+	}
+	return width
+}
+
+// detailLines returns the content of the detail view, one entry per line
+func (m Model) detailLines() []string {
+	task := m.findTask(m.detail.taskID)
+	if task == nil {
+		return []string{lipgloss.NewStyle().Foreground(colorMuted).Render("The task is no longer on the board")}
+	}
+	return strings.Split(m.renderDetail(*task, m.detailWidth(), m.detail.notes), "\n")
+}
+
+// viewDetail renders the full-screen, read-only task detail view
+func (m Model) viewDetail() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("🔎 Task #%d", m.detail.taskID)))
+	b.WriteString("\n")
+
+	lines := m.detailLines()
+	page := m.detailPageHeight()
+	start := m.detail.scroll
+	if start > len(lines) {
+		start = len(lines)
+	}
+	end := start + page
+	if end > len(lines) {
+		end = len(lines)
+	}
+	b.WriteString(strings.Join(lines[start:end], "\n"))
+	for i := end - start; i < page; i++ {
+		b.WriteString("\n")
+	}
+
+	position := ""
+	if len(lines) > page {
+		position = fmt.Sprintf("Lines %d-%d of %d  |  ", start+1, end, len(lines))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render(position + m.keys.footer([]footerItem{
+		{[]string{"up", "down"}, "Scroll"},
+		{[]string{"page_up", "page_down"}, "Page"},
+		{[]string{"description"}, "Edit description"},
+		{[]string{"cancel"}, "Back"},
+	})))
+	return b.String()
+}
+
+// detailPaneWidth returns the width of the detail pane beside the board, or
+// 0 when it is hidden
+func (m Model) detailPaneWidth() int {
+	if !m.showDetailPane || m.width <= 0 {
+		return 0
+	}
+	width := m.width / 3
+	if width < minDetailPaneWidth {
+		width = minDetailPaneWidth
+	}
+	if width > maxDetailPaneWidth {
+		width = maxDetailPaneWidth
+	}
+	// Leave room for at least one column
+	if width > m.width-minColumnWidth {
+		width = m.width - minColumnWidth
+	}
+	if width < 0 {
+		width = 0
+	}
+	return width
+}
+
+// renderDetailPane renders the selected task beside the board, cut to height
+func (m Model) renderDetailPane(width, height int) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Padding(0, 1)
+	innerWidth := width - style.GetHorizontalFrameSize()
+	innerHeight := height - style.GetVerticalFrameSize()
+	if innerWidth < 1 || innerHeight < 1 {
+		return ""
+	}
+
+	var content string
+	if task := m.getCurrentTask(); task != nil {
+		content = m.renderDetail(*task, innerWidth, nil)
+	} else {
+		content = lipgloss.NewStyle().Foreground(colorMuted).Italic(true).Render("No task selected")
+	}
+
+	lines := strings.Split(content, "\n")
+	if len(lines) > innerHeight {
+		lines = lines[:innerHeight-1]
+		more := fmt.Sprintf("… %s: full details", m.keys.label("detail"))
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorMuted).Render(more))
+	}
+	return style.
+		Width(innerWidth + style.GetHorizontalPadding()).
+		Height(innerHeight).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderDetail renders every field of a task wrapped to width. Timer notes
+// are listed when given.
+func (m Model) renderDetail(task model.Task, width int, entries []model.TimeEntry) string {
+	var b strings.Builder
+	label := lipgloss.NewStyle().Foreground(colorMuted).Width(11)
+	heading := lipgloss.NewStyle().Bold(true).Foreground(colorSecondary)
+	row := func(name, value string) {
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, label.Render(name), value))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(lipgloss.NewStyle().Bold(true).Width(width).Render(task.Title))
+	b.WriteString("\n\n")
+
+	status := string(task.Status)
+	for _, col := range m.columns {
+		if col.Status == task.Status {
+			status = lipgloss.NewStyle().Foreground(columnColor(col.Status)).Render(col.Name)
+		}
+	}
+	row("Status", status)
+	if len(task.Tags) > 0 {
+		tags := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			tags[i] = renderTag(tag, nil)
+		}
+		row("Tags", lipgloss.NewStyle().Width(width-label.GetWidth()).Render(strings.Join(tags, " ")))
+	}
+	if task.Due != nil {
+		dueText := due.Format(*task.Due)
+		if task.Status != model.StatusDone {
+			dueText += " · " + due.Relative(*task.Due, m.currentTime)
+		}
+		row("Due", m.dueStyle(task).Render(dueText))
+	}
+	if task.Estimate != nil {
+		row("Estimate", m.cfg.Estimate.Unit.Format(*task.Estimate))
+	}
+	if task.SprintID != nil {
+		for _, s := range m.sprints {
+			if s.ID == *task.SprintID {
+				row("Sprint", s.Name)
+			}
+		}
+	}
+	if tracked := task.TrackedAt(m.currentTime); tracked > 0 {
+		row("Tracked", formatClock(tracked))
+	}
+	if task.Pomodoros > 0 {
+		row("Pomodoros", fmt.Sprintf("%d", task.Pomodoros))
+	}
+	row("Created", task.CreatedAt.Local().Format("2006-01-02 15:04"))
+	row("Updated", task.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	if task.Status != model.StatusDone {
+		row("In column", m.renderAge(task))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(heading.Render("Description"))
+	b.WriteString("\n")
+	if strings.TrimSpace(task.Description) == "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Italic(true).Render("No description"))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(m.markdown.render(task.Description, width))
+		b.WriteString("\n")
+	}
+
+	if done, total := checklistProgress(task.Description); total > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Checklist"))
+		b.WriteString(fmt.Sprintf("  %d/%d done\n", done, total))
+	}
+
+	if links := linkPattern.FindAllString(task.Description, -1); len(links) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Links"))
+		b.WriteString("\n")
+		for _, link := range links {
+			b.WriteString(lipgloss.NewStyle().Underline(true).Width(width).Render(link))
+			b.WriteString("\n")
+		}
+	}
+
+	var notes []model.TimeEntry
+	for _, e := range entries {
+		if e.Note != "" {
+			notes = append(notes, e)
+		}
+	}
+	if len(notes) > 0 {
+		b.WriteString("\n")
+		b.WriteString(heading.Render("Notes"))
+		b.WriteString("\n")
+		for _, e := range notes {
+			when := lipgloss.NewStyle().Foreground(colorMuted).Render(
+				fmt.Sprintf("%s (%s)", e.StartedAt.Local().Format("2006-01-02 15:04"), formatClock(e.Duration(m.currentTime))))
+			b.WriteString(when)
+			b.WriteString("\n")
+			b.WriteString(lipgloss.NewStyle().Width(width).PaddingLeft(2).Render(e.Note))
+			b.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// checklistProgress counts Markdown task list items ("- [ ]" and "- [x]")
+func checklistProgress(description string) (done, total int) {
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "- ") && !strings.HasPrefix(line, "* ") {
+			continue
+		}
+		item := strings.TrimSpace(line[2:])
+		switch {
+		case strings.HasPrefix(item, "[ ]"):
+			total++
+		case strings.HasPrefix(item, "[x]"), strings.HasPrefix(item, "[X]"):
+			done++
+			total++
+		}
+	}
+	return done, total
+}
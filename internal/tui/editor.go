@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/taskfile"
+)
+
+// failedEdit is editor text that did not parse, kept so the next edit of
+// the task reopens it instead of losing the changes
+type failedEdit struct {
+	taskID int64
+	text   string
+}
+
+type editorFinishedMsg struct {
+	taskID   int64
+	path     string
+	original model.TaskEdit
+	err      error
+}
+
+// openEditor suspends the TUI and edits the selected task in $VISUAL or
+// $EDITOR
+func (m Model) openEditor() (Model, tea.Cmd) {
+	task := m.getCurrentTask()
+	if task == nil {
+		return m, nil
+	}
+
+	original := taskfile.Normalize(task.Edit())
+	text := taskfile.Format(original)
+	if m.failedEdit != nil && m.failedEdit.taskID == task.ID {
+		text = m.failedEdit.text
+	}
+
+	path, err := taskfile.WriteTemp(text)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	taskID := task.ID
+	return m, tea.ExecProcess(taskfile.EditorCommand(path), func(err error) tea.Msg {
+		return editorFinishedMsg{taskID: taskID, path: path, original: original, err: err}
+	})
+}
+
+// finishEdit reads the edited file back and saves any changes
+func (m Model) finishEdit(msg editorFinishedMsg) (Model, tea.Cmd) {
+	data, readErr := os.ReadFile(msg.path)
+	os.Remove(msg.path)
+	if msg.err != nil {
+		m.err = fmt.Errorf("failed to run editor: %w", msg.err)
+		return m, nil
+	}
+	if readErr != nil {
+		m.err = fmt.Errorf("failed to read edited task: %w", readErr)
+		return m, nil
+	}
+
+	edit, err := taskfile.Parse(string(data))
+	if err != nil {
+		// Keep the text with the error on top for the next attempt
+		m.failedEdit = &failedEdit{taskID: msg.taskID, text: taskfile.WithProblem(string(data), err)}
+		m.err = fmt.Errorf("task #%d not saved: %w (press %s to fix it)", msg.taskID, err, m.keys.label("editor"))
+		return m, nil
+	}
+	m.failedEdit = nil
+	m.err = nil
+
+	if edit.Equal(msg.original) {
+		return m, nil
+	}
+	m.followTaskID = msg.taskID
+	return m, m.applyEdit(msg.taskID, msg.original, edit)
+}
+
+// applyEdit saves the fields an editor edit changed in one transaction
+func (m Model) applyEdit(id int64, original, edit model.TaskEdit) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.ApplyTaskEdit(id, original, edit); err != nil {
+			return errMsg{err}
+		}
+		return taskUpdatedMsg{}
+	}
+}
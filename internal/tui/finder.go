@@ -0,0 +1,253 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/due"
+	"github.com/happytaoer/cli_kanban/internal/fuzzy"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// maxFinderResults caps how many matches the finder lists
+const maxFinderResults = 12
+
+// maxPreviewLines caps how much of a description the preview shows
+const maxPreviewLines = 12
+
+// finderResult is a task matched in the finder
+type finderResult struct {
+	task  model.Task
+	text  string // "#ID title tags", the text matched against
+	match fuzzy.Match
+}
+
+// finderText is the text the finder matches a task against
+func finderText(task model.Task) string {
+	text := fmt.Sprintf("#%d %s", task.ID, task.Title)
+	if len(task.Tags) > 0 {
+		text += "  " + strings.Join(task.Tags, " ")
+	}
+	return text
+}
+
+// openFinder shows the fuzzy task finder
+func (m Model) openFinder() Model {
+	m.viewMode = ViewModeFinder
+	m.finderInput.SetValue("")
+	m.finderInput.Focus()
+	m.finderCursor = 0
+	m.updateFinderResults()
+	return m
+}
+
+// updateFinderResults ranks every task on the board against the finder input
+func (m *Model) updateFinderResults() {
+	var tasks []model.Task
+	var texts []string
+	for _, col := range m.columns {
+		for _, task := range col.Tasks {
+			tasks = append(tasks, task)
+			texts = append(texts, finderText(task))
+		}
+	}
+
+	m.finderResults = m.finderResults[:0]
+	for _, r := range fuzzy.Rank(strings.TrimSpace(m.finderInput.Value()), texts) {
+		m.finderResults = append(m.finderResults, finderResult{task: tasks[r.Index], text: texts[r.Index], match: r.Match})
+		if len(m.finderResults) == maxFinderResults {
+			break
+		}
+	}
+	if m.finderCursor >= len(m.finderResults) {
+		m.finderCursor = 0
+	}
+}
+
+// handleFinderKeys handles keyboard input in the task finder
+func (m Model) handleFinderKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.input(msg.String()) {
+	case "submit":
+		m.viewMode = ViewModeBoard
+		if m.finderCursor < len(m.finderResults) {
+			m = m.jumpToTask(m.finderResults[m.finderCursor].task.ID)
+		}
+		return m, nil
+
+	case "cancel":
+		m.viewMode = ViewModeBoard
+		return m, nil
+
+	case "prev":
+		if m.finderCursor > 0 {
+			m.finderCursor--
+		}
+		return m, nil
+
+	case "next":
+		if m.finderCursor < len(m.finderResults)-1 {
+			m.finderCursor++
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.finderInput, cmd = m.finderInput.Update(msg)
+	m.updateFinderResults()
+	return m, cmd
+}
+
+// jumpToTask selects a task on the board and scrolls it into view. Filters
+// hiding the task are cleared first.
+func (m Model) jumpToTask(id int64) Model {
+	if col, visible := m.locateTask(id); col >= 0 && !visible {
+		m.sprintScope = SprintScopeAll
+		m, _ = m.applyView(nil)
+	}
+
+	col, ok := m.locateTask(id)
+	if !ok {
+		return m
+	}
+	m.currentColumn = col
+	for i, idx := range m.visibleTaskIndices(col) {
+		if m.columns[col].Tasks[idx].ID == id {
+			m.currentTask = i
+			break
+		}
+	}
+	m.ensureTaskVisible()
+	return m
+}
+
+// locateTask returns the column holding a task and whether the task is
+// visible there; the column is -1 when the task is not on the board
+func (m Model) locateTask(id int64) (int, bool) {
+	for c, col := range m.columns {
+		for i, task := range col.Tasks {
+			if task.ID != id {
+				continue
+			}
+			if !m.columnShown(c) {
+				return c, false
+			}
+			for _, idx := range m.visibleTaskIndices(c) {
+				if idx == i {
+					return c, true
+				}
+			}
+			return c, false
+		}
+	}
+	return -1, false
+}
+
+// viewFinder renders the finder with a preview of the highlighted task
+func (m Model) viewFinder() string {
+	var b strings.Builder
+
+	title := titleStyle.Render("🔎 Go to Task")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(inputStyle.Render(m.finderInput.View()))
+	b.WriteString("\n\n")
+
+	listWidth := m.width / 2
+	if listWidth < 30 {
+		listWidth = 30
+	}
+	previewWidth := m.width - listWidth - 6
+	if previewWidth < 20 {
+		previewWidth = 20
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(colorMuted)
+	var list strings.Builder
+	if len(m.finderResults) == 0 {
+		list.WriteString(mutedStyle.Render("No matching tasks"))
+	}
+	for i, r := range m.finderResults {
+		line := "  " + renderFinderText(r, listWidth-2)
+		if i == m.finderCursor {
+			line = "▸ " + renderFinderText(r, listWidth-2)
+		}
+		list.WriteString(line)
+		list.WriteString("\n")
+	}
+
+	listView := lipgloss.NewStyle().Width(listWidth).Render(list.String())
+	preview := ""
+	if m.finderCursor < len(m.finderResults) {
+		preview = m.renderPreview(m.finderResults[m.finderCursor].task, previewWidth)
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, listView, preview))
+	b.WriteString("\n\n")
+
+	help := helpStyle.Render("Type to filter | " + m.keys.footer([]footerItem{
+		{[]string{"prev", "next"}, "Select"},
+		{[]string{"submit"}, "Go to task"},
+		{[]string{"cancel"}, "Cancel"},
+	}))
+	b.WriteString(help)
+
+	return b.String()
+}
+
+// renderFinderText renders a result's text truncated to width with the
+// matched characters highlighted
+func renderFinderText(r finderResult, width int) string {
+	runes := []rune(r.text)
+	mask := make([]bool, len(runes))
+	for _, pos := range r.match.Positions {
+		mask[pos] = true
+	}
+	if width > 1 && len(runes) > width {
+		runes, mask = append(runes[:width-1:width-1], '…'), append(mask[:width-1:width-1], false)
+	}
+	return highlightText(string(runes), 0, mask)
+}
+
+// renderPreview renders a task's details and description in a box
+func (m Model) renderPreview(task model.Task, width int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(task.Title))
+	b.WriteString("\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(colorMuted)
+	details := []string{fmt.Sprintf("#%d", task.ID)}
+	for _, col := range m.columns {
+		if col.Status == task.Status {
+			details = append(details, col.Name)
+		}
+	}
+	if task.Due != nil {
+		details = append(details, "📅 "+due.Format(*task.Due))
+	}
+	if task.Estimate != nil {
+		details = append(details, "◆ "+m.cfg.Estimate.Unit.Format(*task.Estimate))
+	}
+	if len(task.Tags) > 0 {
+		details = append(details, strings.Join(task.Tags, ", "))
+	}
+	b.WriteString(mutedStyle.Render(strings.Join(details, " · ")))
+	b.WriteString("\n\n")
+
+	if task.Description == "" {
+		b.WriteString(mutedStyle.Render("No description"))
+	} else {
+		lines := strings.Split(task.Description, "\n")
+		if len(lines) > maxPreviewLines {
+			lines = append(lines[:maxPreviewLines], "…")
+		}
+		b.WriteString(strings.Join(lines, "\n"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Padding(0, 1).
+		Width(width).
+		Render(b.String())
+}
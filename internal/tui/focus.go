@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/config"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// pomodoroPhase is the current interval of the focus mode countdown
+type pomodoroPhase int
+
+const (
+	phaseWork pomodoroPhase = iota
+	phaseBreak
+)
+
+// flashDuration is how long the focus view flashes when an interval ends
+const flashDuration = 2 * time.Second
+
+// focusState holds the pomodoro countdown of focus mode
+type focusState struct {
+	taskID     int64
+	phase      pomodoroPhase
+	startedAt  time.Time     // start of the current interval
+	endsAt     time.Time     // end of the current interval (when running)
+	remaining  time.Duration // time left when paused
+	paused     bool
+	flashUntil time.Time
+	completed  int // pomodoros completed in this session
+}
+
+type pomodoroLoggedMsg struct{}
+
+// enterFocus pins the given task in focus mode and starts a work interval
+func (m Model) enterFocus(task *model.Task) Model {
+	now := m.currentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	m.focus = focusState{
+		taskID:    task.ID,
+		phase:     phaseWork,
+		startedAt: now,
+		endsAt:    now.Add(model.PomodoroWork),
+	}
+	m.viewMode = ViewModeFocus
+	return m
+}
+
+// focusTask returns the task pinned in focus mode
+func (m Model) focusTask() *model.Task {
+	return m.findTask(m.focus.taskID)
+}
+
+// focusRemaining returns the time left in the current interval
+func (m Model) focusRemaining() time.Duration {
+	if m.focus.paused {
+		return m.focus.remaining
+	}
+	left := m.focus.endsAt.Sub(m.currentTime)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// tickFocus advances the pomodoro countdown, switching intervals when one ends
+func (m Model) tickFocus() (Model, tea.Cmd) {
+	if m.viewMode != ViewModeFocus || m.focus.paused || m.currentTime.Before(m.focus.endsAt) {
+		return m, nil
+	}
+	return m.nextInterval(true)
+}
+
+// nextInterval switches between work and break. An interval that ran to the
+// end rings the bell, flashes the view and, for work, is logged as a pomodoro.
+func (m Model) nextInterval(completed bool) (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	now := m.currentTime
+	if m.focus.phase == phaseWork {
+		if completed {
+			m.focus.completed++
+			cmds = append(cmds, m.logPomodoro(m.focus.taskID, m.focus.startedAt, m.focus.endsAt))
+		}
+		m.focus.phase = phaseBreak
+		m.focus.endsAt = now.Add(model.PomodoroBreak)
+	} else {
+		m.focus.phase = phaseWork
+		m.focus.endsAt = now.Add(model.PomodoroWork)
+	}
+	m.focus.startedAt = now
+	if completed {
+		m.focus.flashUntil = now.Add(flashDuration)
+		var bell tea.Cmd
+		m, bell = m.ringBell()
+		cmds = append(cmds, bell)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleFocusKeys handles keyboard input in focus mode
+func (m Model) handleFocusKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.screen(config.ContextFocus, msg.String()) {
+	case "focus_pause":
+		if m.focus.paused {
+			m.focus.endsAt = m.currentTime.Add(m.focus.remaining)
+			m.focus.paused = false
+		} else {
+			m.focus.remaining = m.focusRemaining()
+			m.focus.paused = true
+		}
+		return m, nil
+
+	case "focus_skip":
+		// Skip the rest of the current interval without logging it
+		m.focus.paused = false
+		return m.nextInterval(false)
+	}
+
+	if m.keys.closes(msg.String(), "focus") {
+		return m.leaveFocus(), nil
+	}
+	return m, nil
+}
+
+// leaveFocus returns to the board, abandoning the interval in progress
+func (m Model) leaveFocus() Model {
+	m.focus = focusState{}
+	m.viewMode = ViewModeBoard
+	return m
+}
+
+// logPomodoro records a completed pomodoro
+func (m Model) logPomodoro(taskID int64, startedAt, completedAt time.Time) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := m.db.LogPomodoro(taskID, startedAt, completedAt); err != nil {
+			return errMsg{err}
+		}
+		return pomodoroLoggedMsg{}
+	}
+}
+
+// bellDuration is how long the bell stays in the view, long enough for the
+// renderer to draw it in a frame
+const bellDuration = 100 * time.Millisecond
+
+// bellDoneMsg takes a rung bell out of the view
+type bellDoneMsg struct{ bell int }
+
+// ringBell sounds the terminal bell. The bell character goes out with the
+// next frame: the renderer owns the terminal, so writing to it from a
+// command would race with the frames and is dropped in the alt screen.
+func (m Model) ringBell() (Model, tea.Cmd) {
+	m.bells++
+	m.ringing = true
+	bell := m.bells
+	return m, tea.Tick(bellDuration, func(time.Time) tea.Msg {
+		return bellDoneMsg{bell}
+	})
+}
+
+// viewFocus renders the selected task full-screen with the pomodoro countdown
+func (m Model) viewFocus() string {
+	task := m.focusTask()
+	if task == nil {
+		return helpStyle.Render(fmt.Sprintf("Task no longer exists. Press %s to return to the board.", m.keys.label("cancel")))
+	}
+
+	width := m.width
+	if width <= 0 {
+		width = 80
+	}
+	height := m.height
+	if height <= 0 {
+		height = 24
+	}
+	contentWidth := width - 8
+	if contentWidth > 70 {
+		contentWidth = 70
+	}
+	if contentWidth < 20 {
+		contentWidth = 20
+	}
+
+	var b strings.Builder
+
+	phaseLabel := "🍅 FOCUS"
+	phaseColor := colorDanger
+	total := model.PomodoroWork
+	if m.focus.phase == phaseBreak {
+		phaseLabel = "☕ BREAK"
+		phaseColor = colorSuccess
+		total = model.PomodoroBreak
+	}
+	if m.focus.paused {
+		phaseLabel += " (paused)"
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(phaseColor).Render(phaseLabel))
+	b.WriteString("\n\n")
+
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(wrapText(task.Title, contentWidth)))
+	b.WriteString("\n\n")
+
+	remaining := m.focusRemaining()
+	countdown := fmt.Sprintf("%02d:%02d", int(remaining/time.Minute), int(remaining%time.Minute/time.Second))
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(phaseColor).Render(countdown))
+	b.WriteString("\n")
+	b.WriteString(renderProgressBar(total-remaining, total, contentWidth))
+	b.WriteString("\n\n")
+
+	counts := fmt.Sprintf("Session: %d 🍅 | Total on task: %d 🍅", m.focus.completed, task.Pomodoros)
+	b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Render(counts))
+	b.WriteString("\n\n")
+
+	if task.Description != "" {
+		desc := wrapText(task.Description, contentWidth)
+		lines := strings.Split(desc, "\n")
+		maxLines := height - 16
+		if maxLines < 1 {
+			maxLines = 1
+		}
+		if len(lines) > maxLines {
+			lines = append(lines[:maxLines], "…")
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(strings.Join(lines, "\n")))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(helpStyle.Render(m.keys.footer([]footerItem{
+		{[]string{"focus_pause"}, "Pause/Resume"},
+		{[]string{"focus_skip"}, "Skip interval"},
+		{[]string{"cancel"}, "Leave focus"},
+	})))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(phaseColor).
+		Padding(1, 3).
+		Width(contentWidth + 6).
+		Align(lipgloss.Center)
+	if m.currentTime.Before(m.focus.flashUntil) {
+		// Flash the card when an interval completes
+		box = box.Background(phaseColor).Foreground(colorOnPrimary)
+	}
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box.Render(b.String()))
+}
+
+// renderProgressBar renders a horizontal bar of done over total
+func renderProgressBar(done, total time.Duration, width int) string {
+	if total <= 0 || width <= 0 {
+		return ""
+	}
+	filled := int(float64(width) * float64(done) / float64(total))
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + lipgloss.NewStyle().Foreground(colorBorder).Render(strings.Repeat("░", width-filled))
+}
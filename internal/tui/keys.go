@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/happytaoer/cli_kanban/internal/config"
+)
+
+// keymap resolves key presses to the configured binding names
+type keymap struct {
+	bindings []config.Binding
+	actions  map[string]map[string]string // context -> key -> binding name
+	keys     map[string][]string          // binding name -> keys
+}
+
+// newKeymap indexes bindings by context and key
+func newKeymap(bindings []config.Binding) keymap {
+	k := keymap{
+		bindings: bindings,
+		actions:  make(map[string]map[string]string),
+		keys:     make(map[string][]string),
+	}
+	for _, b := range bindings {
+		if k.actions[b.Context] == nil {
+			k.actions[b.Context] = make(map[string]string)
+		}
+		for _, key := range b.Keys {
+			k.actions[b.Context][key] = b.Name
+		}
+		k.keys[b.Name] = b.Keys
+	}
+	return k
+}
+
+// board returns the board binding a key triggers, or ""
+func (k keymap) board(key string) string {
+	return k.actions[config.ContextBoard][key]
+}
+
+// input returns the text input binding a key triggers, or ""
+func (k keymap) input(key string) string {
+	return k.actions[config.ContextInput][key]
+}
+
+// confirm returns the confirmation binding a key triggers, or ""
+func (k keymap) confirm(key string) string {
+	return k.actions[config.ContextConfirm][key]
+}
+
+// screen returns the binding a key triggers in a full-screen view's own
+// context, or ""
+func (k keymap) screen(context, key string) string {
+	return k.actions[context][key]
+}
+
+// closes reports whether a key leaves a full-screen view: the board's quit
+// key or the board binding that opened the view. The cancel key is handled
+// before the view sees it.
+func (k keymap) closes(key, opener string) bool {
+	action := k.board(key)
+	return action == "quit" || action == opener
+}
+
+// label returns the display name of a binding's first key
+func (k keymap) label(name string) string {
+	keys := k.keys[name]
+	if len(keys) == 0 {
+		return ""
+	}
+	return keyLabel(keys[0])
+}
+
+// labels returns the display names of all of a binding's keys
+func (k keymap) labels(name string) string {
+	names := make([]string, len(k.keys[name]))
+	for i, key := range k.keys[name] {
+		names[i] = keyLabel(key)
+	}
+	return strings.Join(names, " or ")
+}
+
+// keyLabels are display names for keys whose terminal name reads poorly
+var keyLabels = map[string]string{
+	"left":      "←",
+	"right":     "→",
+	"up":        "↑",
+	"down":      "↓",
+	"enter":     "Enter",
+	"esc":       "Esc",
+	"delete":    "Delete",
+	"backspace": "Backspace",
+	"tab":       "Tab",
+	"pgup":      "PgUp",
+	"pgdown":    "PgDn",
+	"home":      "Home",
+	"end":       "End",
+	" ":         "Space",
+}
+
+// keyLabel returns the display name of a key, e.g. "Ctrl+P" for "ctrl+p"
+func keyLabel(key string) string {
+	if label, ok := keyLabels[key]; ok {
+		return label
+	}
+	if rest, ok := strings.CutPrefix(key, "ctrl+"); ok {
+		return "Ctrl+" + strings.ToUpper(rest)
+	}
+	if rest, ok := strings.CutPrefix(key, "alt+"); ok {
+		return "Alt+" + keyLabel(rest)
+	}
+	if len(key) > 1 && key[0] == 'f' {
+		return strings.ToUpper(key)
+	}
+	return key
+}
+
+// footerItem is a footer hint: the first key of each binding and a label
+type footerItem struct {
+	bindings []string
+	label    string
+}
+
+// boardFooter is the footer of the unfiltered board
+var boardFooter = []footerItem{
+	{[]string{"left", "right"}, "Navigate"},
+	{[]string{"add"}, "Add"},
+	{[]string{"edit"}, "Edit"},
+	{[]string{"description"}, "Desc"},
+	{[]string{"tags"}, "Tags"},
+	{[]string{"due"}, "Due"},
+	{[]string{"estimate"}, "Est"},
+	{[]string{"delete"}, "Del"},
+	{[]string{"move"}, "Move"},
+	{[]string{"archive"}, "Archive"},
+	{[]string{"timer"}, "Timer"},
+	{[]string{"focus"}, "Focus"},
+	{[]string{"sprints"}, "Sprints"},
+	{[]string{"stats"}, "Stats"},
+	{[]string{"detail"}, "Details"},
+	{[]string{"search"}, "Search"},
+	{[]string{"find"}, "Find"},
+	{[]string{"views"}, "Views"},
+	{[]string{"palette"}, "Commands"},
+	{[]string{"refresh"}, "Refresh"},
+	{[]string{"help"}, "Help"},
+	{[]string{"quit"}, "Quit"},
+}
+
+// filterFooter is the footer while a filter or view is active
+var filterFooter = []footerItem{
+	{[]string{"search"}, "Search"},
+	{[]string{"views"}, "Views"},
+	{[]string{"clear_filter"}, "Clear filter"},
+	{[]string{"refresh"}, "Refresh"},
+	{[]string{"left", "right"}, "Navigate"},
+	{[]string{"add"}, "Add"},
+	{[]string{"edit"}, "Edit"},
+	{[]string{"palette"}, "Commands"},
+	{[]string{"help"}, "Help"},
+	{[]string{"quit"}, "Quit"},
+}
+
+// selectionFooter is the footer while tasks are selected
+var selectionFooter = []footerItem{
+	{[]string{"select"}, "Toggle"},
+	{[]string{"select_range"}, "Range"},
+	{[]string{"select_column"}, "Column"},
+	{[]string{"move"}, "Move"},
+	{[]string{"tags"}, "Tags"},
+	{[]string{"due"}, "Due"},
+	{[]string{"archive"}, "Archive"},
+	{[]string{"delete"}, "Del"},
+	{[]string{"clear_filter"}, "Clear"},
+}
+
+// saveFooter is the hint line of prompts that save their input
+var saveFooter = []footerItem{
+	{[]string{"submit"}, "Save"},
+	{[]string{"cancel"}, "Cancel"},
+}
+
+// footer renders footer hints with the active keys
+func (k keymap) footer(items []footerItem) string {
+	hints := make([]string, 0, len(items))
+	for _, item := range items {
+		keys := make([]string, len(item.bindings))
+		for i, name := range item.bindings {
+			keys[i] = k.label(name)
+		}
+		label := strings.Join(keys, " ")
+		// Space symbol keys off the colon: "/ : Search"
+		if r, _ := utf8.DecodeLastRuneInString(label); !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			label += " "
+		}
+		hints = append(hints, label+": "+item.label)
+	}
+	return strings.Join(hints, " | ")
+}
+
+// helpSection renders the bindings of a help group, one per line
+func (k keymap) helpSection(group string) string {
+	var b strings.Builder
+	for _, binding := range k.bindings {
+		if binding.Group == group {
+			fmt.Fprintf(&b, "  %-14s%s\n", k.labels(binding.Name), binding.Help)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Column layout bounds, in terminal cells including the border
+const (
+	minColumnWidth     = 24 // narrower terminals scroll the board horizontally
+	maxColumnWidth     = 60 // wider terminals leave the remainder empty
+	defaultColumnWidth = 32 // before the first tea.WindowSizeMsg
+)
+
+// Column chrome around the cards: border, padding, title and the two scroll
+// indicator lines
+const (
+	columnChromeWidth  = 2 + 4 // border + horizontal padding
+	columnChromeHeight = 2 + 2 + 2 + 2
+	cardPadding        = 2
+)
+
+// defaultVisibleTasks is how many cards a column shows before the terminal
+// size is known
+const defaultVisibleTasks = 10
+
+// shownColumns returns the indices of the columns the active view displays
+func (m Model) shownColumns() []int {
+	var shown []int
+	for i := range m.columns {
+		if m.columnShown(i) {
+			shown = append(shown, i)
+		}
+	}
+	return shown
+}
+
+// boardWidth returns the width left for the columns beside the detail pane
+func (m Model) boardWidth() int {
+	return m.width - m.detailPaneWidth()
+}
+
+// columnsPerScreen returns how many columns fit side by side
+func (m Model) columnsPerScreen() int {
+	n := len(m.shownColumns())
+	if m.width <= 0 || n == 0 {
+		return n
+	}
+	fit := m.boardWidth() / minColumnWidth
+	if fit < 1 {
+		fit = 1
+	}
+	if fit > n {
+		fit = n
+	}
+	return fit
+}
+
+// columnWidth returns the width of a column including its border
+func (m Model) columnWidth() int {
+	if m.width <= 0 {
+		return defaultColumnWidth
+	}
+	perScreen := m.columnsPerScreen()
+	if perScreen == 0 {
+		return defaultColumnWidth
+	}
+	width := m.boardWidth() / perScreen
+	if width > maxColumnWidth {
+		width = maxColumnWidth
+	}
+	return width
+}
+
+// cardWidth returns the width of a card including its padding
+func (m Model) cardWidth() int {
+	return m.columnWidth() - columnChromeWidth
+}
+
+// visibleColumns returns the shown columns that fit on screen: all of them,
+// or a window that keeps the focused column in view
+func (m Model) visibleColumns() (columns []int, hiddenLeft, hiddenRight int) {
+	shown := m.shownColumns()
+	perScreen := m.columnsPerScreen()
+	if perScreen >= len(shown) {
+		return shown, 0, 0
+	}
+
+	// Keep the window where it is unless the focused column left it
+	focus := 0
+	for i, c := range shown {
+		if c == m.currentColumn {
+			focus = i
+		}
+	}
+	first := m.columnOffset
+	if focus < first {
+		first = focus
+	} else if focus >= first+perScreen {
+		first = focus - perScreen + 1
+	}
+	if first > len(shown)-perScreen {
+		first = len(shown) - perScreen
+	}
+	if first < 0 {
+		first = 0
+	}
+	return shown[first : first+perScreen], first, len(shown) - first - perScreen
+}
+
+// boardHeight returns the lines left for the columns between the header and
+// the footer. It renders both, so callers compute it once and pass it on.
+func (m Model) boardHeight() int {
+	return m.boardHeightBetween(m.renderHeader(), m.renderFooter())
+}
+
+// boardHeightBetween returns the lines left for the columns between an
+// already rendered header and footer
+func (m Model) boardHeightBetween(header, footer string) int {
+	height := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if _, left, right := m.visibleColumns(); left > 0 || right > 0 {
+		height-- // horizontal scroll hint
+	}
+	if m.err != nil {
+		height -= 2
+	}
+	if height < 1 {
+		height = 1
+	}
+	return height
+}
+
+// tasksThatFit returns how many of a column's visible tasks, starting at
+// offset, fit in a board of the given height; at least one is always shown
+func (m Model) tasksThatFit(column, offset, boardHeight int) int {
+	indices := m.visibleTaskIndices(column)
+	if m.height <= 0 {
+		return defaultVisibleTasks
+	}
+
+	available := boardHeight - columnChromeHeight
+	count := 0
+	for i := offset; i < len(indices); i++ {
+		task := m.columns[column].Tasks[indices[i]]
+		height := lipgloss.Height(m.renderTask(task, false)) // includes the margin line
+		if count > 0 && height > available {
+			break
+		}
+		available -= height
+		count++
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+// pullUpScroll scrolls a column back up while that shows more cards without
+// leaving empty space below the last one
+func (m *Model) pullUpScroll(column, boardHeight int) {
+	count := len(m.visibleTaskIndices(column))
+	offset := m.scrollOffsets[column]
+	if offset >= count {
+		offset = count - 1
+	}
+	for offset > 0 && offset-1+m.tasksThatFit(column, offset-1, boardHeight) >= count {
+		offset--
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	m.scrollOffsets[column] = offset
+}
+
+// reflow re-fits every column to the terminal size and keeps the selected
+// task in view
+func (m *Model) reflow() {
+	height := m.boardHeight()
+	for i := range m.columns {
+		m.pullUpScroll(i, height)
+	}
+	m.ensureTaskVisible()
+}
@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxMarkdownCache bounds how many rendered descriptions are kept
+const maxMarkdownCache = 64
+
+// markdownRenderer renders descriptions as Markdown. The board redraws
+// every second, so output is cached by text and width.
+type markdownRenderer struct {
+	style     string // glamour standard style, see theme.Theme.Markdown
+	renderers map[int]*glamour.TermRenderer
+	cache     map[markdownKey]string
+}
+
+type markdownKey struct {
+	text  string
+	width int
+}
+
+// newMarkdownRenderer creates a renderer using a glamour standard style
+func newMarkdownRenderer(style string) *markdownRenderer {
+	return &markdownRenderer{
+		style:     style,
+		renderers: make(map[int]*glamour.TermRenderer),
+		cache:     make(map[markdownKey]string),
+	}
+}
+
+// render renders Markdown wrapped to width, falling back to plain wrapped
+// text if the renderer fails
+func (r *markdownRenderer) render(text string, width int) string {
+	key := markdownKey{text, width}
+	if out, ok := r.cache[key]; ok {
+		return out
+	}
+
+	plain := lipgloss.NewStyle().Width(width).Render(text)
+	tr, ok := r.renderers[width]
+	if !ok {
+		var err error
+		tr, err = glamour.NewTermRenderer(
+			glamour.WithStyles(r.styles()),
+			glamour.WithColorProfile(lipgloss.ColorProfile()),
+			glamour.WithWordWrap(width),
+		)
+		if err != nil {
+			return plain
+		}
+		r.renderers[width] = tr
+	}
+	out, err := tr.Render(text)
+	if err != nil {
+		return plain
+	}
+	out = strings.Trim(out, "\n")
+
+	if len(r.cache) >= maxMarkdownCache {
+		r.cache = make(map[markdownKey]string)
+	}
+	r.cache[key] = out
+	return out
+}
+
+// styles returns the glamour style without the document margin, so the
+// output lines up with the surrounding fields
+func (r *markdownRenderer) styles() ansi.StyleConfig {
+	var styles ansi.StyleConfig
+	switch r.style {
+	case "light":
+		styles = glamour.LightStyleConfig
+	case "notty":
+		styles = glamour.NoTTYStyleConfig
+	default:
+		styles = glamour.DarkStyleConfig
+	}
+	var zero uint
+	styles.Document.Margin = &zero
+	styles.Document.BlockPrefix = ""
+	styles.Document.BlockSuffix = ""
+	return styles
+}
+
+// Markdown syntax stripped from excerpts
+var (
+	excerptPrefix = regexp.MustCompile(`^(#{1,6}\s+|>\s*|[-*+]\s+(\[[ xX]\]\s+)?|\d+[.)]\s+)`)
+	excerptInline = regexp.MustCompile("[*_`~]+")
+	excerptLink   = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+)
+
+// descriptionExcerpt returns the first line of text in a description with
+// Markdown syntax removed, cut to width
+func descriptionExcerpt(description string, width int) string {
+	inCode := false
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || line == "" {
+			continue
+		}
+		line = excerptPrefix.ReplaceAllString(line, "")
+		line = excerptLink.ReplaceAllString(line, "$1")
+		line = strings.TrimSpace(excerptInline.ReplaceAllString(line, ""))
+		if line == "" {
+			continue
+		}
+		return truncate(line, width)
+	}
+	return ""
+}
+
+// truncate cuts text to width cells, ending in "…" when shortened
+func truncate(text string, width int) string {
+	if lipgloss.Width(text) <= width {
+		return text
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range text {
+		w := runeWidth(r)
+		if used+w > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "…"
+}
@@ -7,8 +7,11 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/happytaoer/cli_kanban/internal/config"
 	"github.com/happytaoer/cli_kanban/internal/db"
 	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/query"
+	"github.com/happytaoer/cli_kanban/internal/theme"
 )
 
 // ViewMode represents the current view mode
@@ -24,29 +27,81 @@ const (
 	ViewModeConfirmDelete
 	ViewModeHelp
 	ViewModeSearch
+	ViewModeTimerNote
+	ViewModeFocus
+	ViewModeEditEstimate
+	ViewModeSprints
+	ViewModeStats
+	ViewModeViews
+	ViewModeSaveView
+	ViewModeFinder
+	ViewModePalette
+	ViewModeDetail
+	ViewModeCalendar
+)
+
+// Sprint scopes that are not a sprint ID
+const (
+	SprintScopeAll     int64 = 0  // show every task
+	SprintScopeBacklog int64 = -1 // show tasks not assigned to a sprint
 )
 
 // Model is the main TUI model
 type Model struct {
-	db              *db.DB
-	columns         []model.Column
-	currentColumn   int
-	currentTask     int
-	scrollOffsets   []int // scroll offset per column
-	viewMode        ViewMode
-	currentTime     time.Time
-	pendingDeleteID int64 // task ID pending deletion confirmation
-	followTaskID    int64 // task ID to follow after reload
-	textInput       textinput.Model
-	textArea        textarea.Model
-	searchInput     textinput.Model
-	dueInput        textinput.Model
-	searchQuery     string // active search filter
-	viewport        viewport.Model
-	width           int
-	height          int
-	ready           bool // viewport ready flag
-	err             error
+	db               *db.DB
+	cfg              config.Config
+	keys             keymap
+	columns          []model.Column
+	currentColumn    int
+	currentTask      int
+	scrollOffsets    []int // scroll offset per column
+	columnOffset     int   // first shown column on screen when not all fit
+	viewMode         ViewMode
+	currentTime      time.Time
+	pendingDeleteIDs []int64 // task IDs pending deletion confirmation
+	followTaskID     int64   // task ID to follow after reload
+	textInput        textinput.Model
+	textArea         textarea.Model
+	searchInput      textinput.Model
+	dueInput         textinput.Model
+	searchQuery      string                    // active search filter as typed
+	searchFilter     *query.Query              // parsed searchQuery
+	prevSearchQuery  string                    // filter to restore when search input is cancelled
+	searchErr        error                     // parse error of the search input
+	searchHits       map[string]map[int64]bool // full-text matches per text term of searchFilter
+	visible          [][]int                   // visible task indices per column, see refreshVisible
+	sprints          []model.Sprint
+	sprintScope      int64 // SprintScopeAll, SprintScopeBacklog or a sprint ID
+	sprintCursor     int   // highlighted entry in the sprint switcher
+	views            []model.SavedView
+	activeView       *model.SavedView // applied saved view, if any
+	viewCursor       int              // highlighted row in the view picker
+	finderInput      textinput.Model
+	finderResults    []finderResult
+	finderCursor     int
+	paletteInput     textinput.Model
+	paletteItems     []paletteItem
+	paletteCursor    int
+	paletteAction    *action  // action waiting for its argument, if any
+	paletteHistory   []string // commands run this session, newest first
+	stats            statsData
+	focus            focusState
+	reminders        reminderState
+	bells            int            // bells rung so far, see ringBell
+	ringing          bool           // the view carries the bell character
+	drag             mouseDrag      // card held down with the left mouse button
+	selected         map[int64]bool // tasks bulk actions apply to, see selection.go
+	selectAnchor     int64          // task last toggled, where range selection starts
+	detail           detailState
+	calendar         calendarState
+	showDetailPane   bool // detail pane beside the board
+	markdown         *markdownRenderer
+	failedEdit       *failedEdit // editor text that did not parse, if any
+	viewport         viewport.Model
+	width            int
+	height           int
+	ready            bool // viewport ready flag
+	err              error
 }
 
 // clockTickCmd creates a command that emits time ticks every second
@@ -57,7 +112,7 @@ func clockTickCmd() tea.Cmd {
 }
 
 // NewModel creates a new TUI model
-func NewModel(database *db.DB) Model {
+func NewModel(database *db.DB, cfg config.Config) Model {
 	ti := textinput.New()
 	ti.Placeholder = "Enter task title..."
 	ti.Focus()
@@ -69,20 +124,36 @@ func NewModel(database *db.DB) Model {
 	ta.Placeholder = "Enter task description..."
 	ta.SetWidth(80)
 	ta.SetHeight(10)
-	ta.CharLimit = 2000
+	ta.CharLimit = 0 // no limit
+	ta.MaxHeight = 0
 
 	si := textinput.New()
 	si.Placeholder = "Search tasks..."
 	si.CharLimit = 100
 	si.Width = 30
 
+	fi := textinput.New()
+	fi.Placeholder = "Task title, tag or #ID..."
+	fi.CharLimit = 100
+	fi.Width = 50
+
+	pi := textinput.New()
+	pi.Placeholder = "Type a command..."
+	pi.CharLimit = 200
+	pi.Width = 50
+
 	di := textinput.New()
-	di.Placeholder = "YYYY-MM-DD (leave empty to clear)"
-	di.CharLimit = 20
+	di.Placeholder = "fri, +3d, next monday 9am..."
+	di.CharLimit = 40
 	di.Width = 30
 
+	activeTheme := theme.Resolve(cfg.Theme.Name, cfg.Theme.Columns)
+	applyTheme(activeTheme)
+
 	return Model{
 		db:            database,
+		cfg:           cfg,
+		keys:          newKeymap(cfg.Keymap()),
 		columns:       model.GetAllColumns(),
 		currentColumn: 0,
 		currentTask:   0,
@@ -93,12 +164,21 @@ func NewModel(database *db.DB) Model {
 		textArea:      ta,
 		searchInput:   si,
 		dueInput:      di,
+		finderInput:   fi,
+		paletteInput:  pi,
+		markdown:      newMarkdownRenderer(activeTheme.Markdown),
 	}
 }
 
+// WithSprintScope returns the model with the board scoped to a sprint
+func (m Model) WithSprintScope(scope int64) Model {
+	m.sprintScope = scope
+	return m
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.loadTasks(), clockTickCmd())
+	return tea.Batch(m.loadTasks(), m.loadSprints(), m.loadViews(), clockTickCmd())
 }
 
 // loadTasks loads all tasks from the database
@@ -112,11 +192,28 @@ func (m Model) loadTasks() tea.Cmd {
 	}
 }
 
+// loadSprints loads all sprints from the database
+func (m Model) loadSprints() tea.Cmd {
+	return func() tea.Msg {
+		sprints, err := m.db.GetSprints()
+		if err != nil {
+			return errMsg{err}
+		}
+		return sprintsLoadedMsg{sprints}
+	}
+}
+
 // Messages
 type tasksLoadedMsg struct {
 	tasks []model.Task
 }
 
+type sprintsLoadedMsg struct {
+	sprints []model.Sprint
+}
+
+type sprintAssignedMsg struct{}
+
 type taskCreatedMsg struct {
 	task *model.Task
 }
@@ -131,15 +228,16 @@ type tagsUpdatedMsg struct{}
 
 type dueUpdatedMsg struct{}
 
+type timerUpdatedMsg struct{}
+
+type estimateUpdatedMsg struct{}
+
 type clockTickMsg time.Time
 
 type errMsg struct {
 	err error
 }
 
-// maxVisibleTasks is the maximum number of tasks visible per column
-const maxVisibleTasks = 10
-
 // getCurrentTask returns the currently selected task (respecting active filters)
 func (m *Model) getCurrentTask() *model.Task {
 	if len(m.columns) == 0 || m.currentColumn < 0 || m.currentColumn >= len(m.columns) {
@@ -160,12 +258,27 @@ func (m *Model) getCurrentTask() *model.Task {
 	return &col.Tasks[actualIdx]
 }
 
+// findTask returns the board task with the given ID
+func (m Model) findTask(id int64) *model.Task {
+	for i := range m.columns {
+		for j := range m.columns[i].Tasks {
+			if m.columns[i].Tasks[j].ID == id {
+				return &m.columns[i].Tasks[j]
+			}
+		}
+	}
+	return nil
+}
+
 // ensureTaskVisible adjusts scroll offset to keep current task visible
 func (m *Model) ensureTaskVisible() {
 	if len(m.columns) == 0 {
 		return
 	}
 
+	// Scroll the board sideways only as far as the focused column needs
+	_, m.columnOffset, _ = m.visibleColumns()
+
 	visibleIndices := m.visibleTaskIndices(m.currentColumn)
 	visibleCount := len(visibleIndices)
 
@@ -182,23 +295,18 @@ func (m *Model) ensureTaskVisible() {
 		m.currentTask = 0
 	}
 
+	// How many cards fit depends on their heights, so walk the offset
+	// rather than computing it
 	offset := m.scrollOffsets[m.currentColumn]
-	maxOffset := visibleCount - maxVisibleTasks
-	if maxOffset < 0 {
-		maxOffset = 0
-	}
-	if offset > maxOffset {
-		offset = maxOffset
-	}
-
 	if m.currentTask < offset {
 		offset = m.currentTask
 	}
-	if m.currentTask >= offset+maxVisibleTasks {
-		offset = m.currentTask - maxVisibleTasks + 1
+	height := m.boardHeight()
+	for m.currentTask >= offset+m.tasksThatFit(m.currentColumn, offset, height) {
+		offset++
 	}
-
 	m.scrollOffsets[m.currentColumn] = offset
+	m.pullUpScroll(m.currentColumn, height)
 }
 
 // organizeTasks organizes tasks into columns by status
@@ -208,8 +316,11 @@ func (m *Model) organizeTasks(tasks []model.Task) {
 		m.columns[i].Tasks = []model.Task{}
 	}
 
-	// Organize tasks by status
+	// Organize tasks by status; archived tasks stay off the board
 	for _, task := range tasks {
+		if task.ArchivedAt != nil {
+			continue
+		}
 		for i := range m.columns {
 			if m.columns[i].Status == task.Status {
 				m.columns[i].Tasks = append(m.columns[i].Tasks, task)
@@ -218,11 +329,20 @@ func (m *Model) organizeTasks(tasks []model.Task) {
 		}
 	}
 
-	// If we're following a task after move, find its position
+	if m.activeView != nil {
+		for i := range m.columns {
+			model.SortTasks(m.columns[i].Tasks, m.activeView.Sort)
+		}
+	}
+	m.refreshVisible()
+	m.pruneSelection()
+
+	// If we're following a task after move, find its position among the
+	// visible tasks
 	if m.followTaskID != 0 {
 		found := false
-		for i, task := range m.columns[m.currentColumn].Tasks {
-			if task.ID == m.followTaskID {
+		for i, idx := range m.visibleTaskIndices(m.currentColumn) {
+			if m.columns[m.currentColumn].Tasks[idx].ID == m.followTaskID {
 				m.currentTask = i
 				found = true
 				break
@@ -240,14 +360,32 @@ func (m *Model) organizeTasks(tasks []model.Task) {
 }
 
 // visibleTaskIndices returns the indices of tasks visible in the given column
-// after applying the current search filter.
+// after applying the sprint scope and the current search filter.
 func (m Model) visibleTaskIndices(columnIndex int) []int {
 	if columnIndex < 0 || columnIndex >= len(m.columns) {
 		return nil
 	}
+	if columnIndex < len(m.visible) {
+		return m.visible[columnIndex]
+	}
+	return m.filterColumn(columnIndex)
+}
+
+// refreshVisible recomputes the cached visible task indices. It must be
+// called whenever the tasks, the search filter or the sprint scope change,
+// so rendering never re-evaluates the filter.
+func (m *Model) refreshVisible() {
+	m.visible = make([][]int, len(m.columns))
+	for i := range m.columns {
+		m.visible[i] = m.filterColumn(i)
+	}
+}
+
+// filterColumn evaluates the sprint scope and search filter over a column
+func (m Model) filterColumn(columnIndex int) []int {
 
 	col := m.columns[columnIndex]
-	if m.searchQuery == "" {
+	if m.searchFilter.Empty() && m.sprintScope == SprintScopeAll {
 		indices := make([]int, len(col.Tasks))
 		for i := range col.Tasks {
 			indices[i] = i
@@ -257,9 +395,26 @@ func (m Model) visibleTaskIndices(columnIndex int) []int {
 
 	indices := make([]int, 0, len(col.Tasks))
 	for i, task := range col.Tasks {
-		if m.matchesSearch(task) {
+		if m.isTaskVisible(task) {
 			indices = append(indices, i)
 		}
 	}
 	return indices
 }
+
+// isTaskVisible reports whether a task passes the sprint scope and search filter
+func (m Model) isTaskVisible(task model.Task) bool {
+	return m.inSprintScope(task) && m.matchesSearch(task)
+}
+
+// inSprintScope reports whether a task belongs to the active sprint scope
+func (m Model) inSprintScope(task model.Task) bool {
+	switch m.sprintScope {
+	case SprintScopeAll:
+		return true
+	case SprintScopeBacklog:
+		return task.SprintID == nil
+	default:
+		return task.SprintID != nil && *task.SprintID == m.sprintScope
+	}
+}
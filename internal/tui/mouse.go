@@ -0,0 +1,157 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// mouseDrag is a card being dragged with the left button held down
+type mouseDrag struct {
+	active bool
+	column int   // column the drag started in
+	taskID int64 // task being dragged
+}
+
+// boardHit is the part of the board under the mouse cursor
+type boardHit struct {
+	column int // column index, -1 outside the columns
+	task   int // position among the column's visible tasks, -1 if not on a card
+}
+
+// hitTest maps a terminal cell to the column and card rendered there,
+// following the layout of viewBoard and renderColumn
+func (m Model) hitTest(x, y int) boardHit {
+	miss := boardHit{column: -1, task: -1}
+
+	// The viewport starts right below the header
+	header := m.renderHeader()
+	y -= lipgloss.Height(header)
+	height := m.boardHeightBetween(header, m.renderFooter())
+	visible, hiddenLeft, hiddenRight := m.visibleColumns()
+	if hiddenLeft > 0 || hiddenRight > 0 {
+		y-- // horizontal scroll hint
+	}
+	// The columns end where the footer starts, above any error message
+	if y < 0 || y >= height || x < 0 {
+		return miss
+	}
+
+	slot := x / m.columnWidth()
+	if slot >= len(visible) {
+		return miss
+	}
+	column := visible[slot]
+	hit := boardHit{column: column, task: -1}
+
+	// Skip the border, top padding and title with its margin
+	row := y - 1 - columnStyle.GetPaddingTop() - 2
+	indices := m.visibleTaskIndices(column)
+	offset := m.scrollOffsets[column]
+	if offset >= len(indices) {
+		offset = 0
+	}
+	if offset > 0 {
+		row-- // "more above" indicator
+	}
+	if row < 0 {
+		return hit
+	}
+
+	end := offset + m.tasksThatFit(column, offset, height)
+	if end > len(indices) {
+		end = len(indices)
+	}
+	for i := offset; i < end; i++ {
+		task := m.columns[column].Tasks[indices[i]]
+		height := lipgloss.Height(m.renderTask(task, false))
+		if row < height-1 { // the last line is the margin between cards
+			hit.task = i
+			return hit
+		}
+		row -= height
+	}
+	return hit
+}
+
+// handleMouse selects cards on click, scrolls columns with the wheel and
+// moves a card dragged onto another column, along with the rest of the
+// selection when the card is selected
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.viewMode != ViewModeBoard {
+		return m, nil
+	}
+
+	hit := m.hitTest(msg.X, msg.Y)
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		return m.scrollColumn(hit.column, -1), nil
+
+	case msg.Button == tea.MouseButtonWheelDown:
+		return m.scrollColumn(hit.column, 1), nil
+
+	case msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+		m.drag = mouseDrag{}
+		if hit.column < 0 {
+			return m, nil
+		}
+		if hit.column != m.currentColumn {
+			m.currentColumn = hit.column
+			m.currentTask = 0
+		}
+		if hit.task >= 0 {
+			m.currentTask = hit.task
+			if task := m.getCurrentTask(); task != nil {
+				m.drag = mouseDrag{active: true, column: hit.column, taskID: task.ID}
+			}
+		}
+		m.ensureTaskVisible()
+		return m, nil
+
+	case msg.Action == tea.MouseActionRelease:
+		drag := m.drag
+		m.drag = mouseDrag{}
+		if !drag.active || hit.column < 0 || hit.column == drag.column {
+			return m, nil
+		}
+		task := m.findTask(drag.taskID)
+		if task == nil {
+			return m, nil
+		}
+		if m.selected[task.ID] {
+			return m.moveSelected(hit.column)
+		}
+		return m.moveToColumn(task, hit.column)
+	}
+	return m, nil
+}
+
+// scrollColumn scrolls a column by delta cards, keeping its selection on
+// screen when it is the current column
+func (m Model) scrollColumn(column, delta int) Model {
+	if column < 0 {
+		return m
+	}
+	count := len(m.visibleTaskIndices(column))
+	offset := m.scrollOffsets[column] + delta
+	if offset > count-1 {
+		offset = count - 1
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	m.scrollOffsets[column] = offset
+	height := m.boardHeight()
+	m.pullUpScroll(column, height)
+
+	if column == m.currentColumn && count > 0 {
+		offset = m.scrollOffsets[column]
+		last := offset + m.tasksThatFit(column, offset, height) - 1
+		if m.currentTask < offset {
+			m.currentTask = offset
+		}
+		if m.currentTask > last {
+			m.currentTask = last
+		}
+	}
+	return m
+}
@@ -0,0 +1,303 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/fuzzy"
+)
+
+// maxPaletteItems caps how many commands the palette lists at once
+const maxPaletteItems = 12
+
+// maxPaletteHistory caps how many recent commands the palette remembers
+const maxPaletteHistory = 20
+
+// paletteItem is a command listed in the palette
+type paletteItem struct {
+	action  action
+	arg     string // argument typed after the action name, if any
+	history bool   // item is a recent command
+	match   fuzzy.Match
+}
+
+// command returns the item as typed: the action name and its argument
+func (it paletteItem) command() string {
+	if it.arg == "" {
+		return it.action.name
+	}
+	return it.action.name + " " + it.arg
+}
+
+// openPalette shows the command palette
+func (m Model) openPalette() Model {
+	m.viewMode = ViewModePalette
+	m.paletteAction = nil
+	m.paletteInput.Placeholder = "Type a command..."
+	m.paletteInput.SetValue("")
+	m.paletteInput.Focus()
+	m.paletteCursor = 0
+	m.updatePaletteItems()
+	return m
+}
+
+// parseCommand splits input into an action taking an argument and the
+// argument, e.g. "add tag urgent"; the longest matching name wins
+func parseCommand(actions []action, input string) (action, string, bool) {
+	lower := strings.ToLower(input)
+	var found action
+	ok := false
+	for _, a := range actions {
+		if a.arg == "" || !strings.HasPrefix(lower, a.name+" ") {
+			continue
+		}
+		if !ok || len(a.name) > len(found.name) {
+			found, ok = a, true
+		}
+	}
+	if !ok {
+		return action{}, "", false
+	}
+	return found, strings.TrimSpace(input[len(found.name):]), true
+}
+
+// updatePaletteItems lists the commands matching the palette input: recent
+// commands and every action when it is empty, otherwise the typed command
+// followed by fuzzy matches of the action names
+func (m *Model) updatePaletteItems() {
+	actions := m.actions()
+	input := strings.TrimSpace(m.paletteInput.Value())
+	m.paletteItems = m.paletteItems[:0]
+
+	if input == "" {
+		for _, cmd := range m.paletteHistory {
+			if a, ok := findAction(actions, cmd); ok {
+				m.paletteItems = append(m.paletteItems, paletteItem{action: a, history: true})
+			} else if a, arg, ok := parseCommand(actions, cmd); ok {
+				m.paletteItems = append(m.paletteItems, paletteItem{action: a, arg: arg, history: true})
+			}
+		}
+		for _, a := range actions {
+			m.paletteItems = append(m.paletteItems, paletteItem{action: a})
+		}
+	} else {
+		if a, arg, ok := parseCommand(actions, input); ok && arg != "" {
+			m.paletteItems = append(m.paletteItems, paletteItem{action: a, arg: arg})
+		}
+		names := make([]string, len(actions))
+		for i, a := range actions {
+			names[i] = a.name
+		}
+		for _, r := range fuzzy.Rank(input, names) {
+			m.paletteItems = append(m.paletteItems, paletteItem{action: actions[r.Index], match: r.Match})
+		}
+	}
+
+	if m.paletteCursor >= len(m.paletteItems) {
+		m.paletteCursor = 0
+	}
+}
+
+// findAction returns the action with a name
+func findAction(actions []action, name string) (action, bool) {
+	for _, a := range actions {
+		if a.name == name {
+			return a, true
+		}
+	}
+	return action{}, false
+}
+
+// handlePaletteKeys handles keyboard input in the command palette
+func (m Model) handlePaletteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.paletteAction != nil {
+		return m.handlePaletteArgKeys(msg)
+	}
+
+	switch m.keys.input(msg.String()) {
+	case "submit":
+		if m.paletteCursor >= len(m.paletteItems) {
+			return m, nil
+		}
+		item := m.paletteItems[m.paletteCursor]
+		if item.action.arg != "" && item.arg == "" {
+			// Prompt for the argument
+			a := item.action
+			m.paletteAction = &a
+			m.paletteInput.Placeholder = a.arg
+			m.paletteInput.SetValue("")
+			return m, nil
+		}
+		return m.runPaletteItem(item)
+
+	case "cancel":
+		m.viewMode = ViewModeBoard
+		return m, nil
+
+	case "prev":
+		if m.paletteCursor > 0 {
+			m.paletteCursor--
+		}
+		return m, nil
+
+	case "next":
+		if m.paletteCursor < len(m.paletteItems)-1 {
+			m.paletteCursor++
+		}
+		return m, nil
+
+	case "complete":
+		// Complete the highlighted action name to type its argument inline
+		if m.paletteCursor < len(m.paletteItems) {
+			item := m.paletteItems[m.paletteCursor]
+			value := item.command()
+			if item.action.arg != "" && item.arg == "" {
+				value += " "
+			}
+			m.paletteInput.SetValue(value)
+			m.paletteInput.CursorEnd()
+			m.updatePaletteItems()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	m.updatePaletteItems()
+	return m, cmd
+}
+
+// handlePaletteArgKeys handles the argument prompt of a palette action
+func (m Model) handlePaletteArgKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.input(msg.String()) {
+	case "submit":
+		arg := strings.TrimSpace(m.paletteInput.Value())
+		if arg == "" {
+			return m, nil
+		}
+		return m.runPaletteItem(paletteItem{action: *m.paletteAction, arg: arg})
+
+	case "cancel":
+		// Back to the command list
+		m.paletteInput.SetValue(m.paletteAction.name)
+		m.paletteInput.CursorEnd()
+		m.paletteInput.Placeholder = "Type a command..."
+		m.paletteAction = nil
+		m.updatePaletteItems()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	return m, cmd
+}
+
+// runPaletteItem closes the palette, records the command in the history and
+// runs it
+func (m Model) runPaletteItem(item paletteItem) (tea.Model, tea.Cmd) {
+	m.viewMode = ViewModeBoard
+	m.paletteAction = nil
+
+	task := m.getCurrentTask()
+	if item.action.needTask && task == nil {
+		m.err = fmt.Errorf("%s: no task selected", item.action.name)
+		return m, nil
+	}
+
+	command := item.command()
+	history := []string{command}
+	for _, h := range m.paletteHistory {
+		if h != command && len(history) < maxPaletteHistory {
+			history = append(history, h)
+		}
+	}
+	m.paletteHistory = history
+
+	m.err = nil
+	return item.action.run(m, task, item.arg)
+}
+
+// viewPalette renders the command palette
+func (m Model) viewPalette() string {
+	var b strings.Builder
+
+	title := titleStyle.Render("⚡ Commands")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(colorMuted)
+
+	if m.paletteAction != nil {
+		name := lipgloss.NewStyle().Foreground(colorSecondary).Render(m.paletteAction.name)
+		b.WriteString(name)
+		b.WriteString("\n\n")
+		b.WriteString(inputStyle.Render(m.paletteInput.View()))
+		b.WriteString("\n")
+		if m.paletteAction.preview != nil {
+			b.WriteString(m.paletteAction.preview(m, m.paletteInput.Value()))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render(m.keys.footer([]footerItem{
+			{[]string{"submit"}, "Run"},
+			{[]string{"cancel"}, "Back"},
+		})))
+		return b.String()
+	}
+
+	b.WriteString(inputStyle.Render(m.paletteInput.View()))
+	b.WriteString("\n\n")
+
+	if len(m.paletteItems) == 0 {
+		b.WriteString(mutedStyle.Render("No matching commands"))
+		b.WriteString("\n")
+	}
+
+	// Scroll the list to keep the cursor in view
+	offset := 0
+	if m.paletteCursor >= maxPaletteItems {
+		offset = m.paletteCursor - maxPaletteItems + 1
+	}
+	for i := offset; i < len(m.paletteItems) && i < offset+maxPaletteItems; i++ {
+		item := m.paletteItems[i]
+		prefix := "  "
+		if i == m.paletteCursor {
+			prefix = "▸ "
+		}
+
+		var line strings.Builder
+		line.WriteString(prefix)
+		if item.history {
+			line.WriteString(mutedStyle.Render("↺ "))
+		}
+		mask := make([]bool, len([]rune(item.action.name)))
+		for _, pos := range item.match.Positions {
+			mask[pos] = true
+		}
+		line.WriteString(highlightText(item.action.name, 0, mask))
+		switch {
+		case item.arg != "":
+			line.WriteString(" " + lipgloss.NewStyle().Foreground(colorSecondary).Render(item.arg))
+		case item.action.arg != "":
+			line.WriteString(mutedStyle.Render(" <" + strings.ToLower(strings.Fields(item.action.arg)[0]) + ">"))
+		}
+		if key := m.keys.label(item.action.binding); key != "" {
+			line.WriteString(mutedStyle.Render("  " + key))
+		}
+		b.WriteString(line.String())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	help := helpStyle.Render("Type to filter | " + m.keys.footer([]footerItem{
+		{[]string{"prev", "next"}, "Select"},
+		{[]string{"complete"}, "Complete"},
+		{[]string{"submit"}, "Run"},
+		{[]string{"cancel"}, "Cancel"},
+	}))
+	b.WriteString(help)
+
+	return b.String()
+}
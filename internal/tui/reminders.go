@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/due"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// Reminder timing: how long the banner stays up, and how long after the due
+// time a reminder may still fire, so a reminder at the due time is not
+// missed between clock ticks
+const (
+	reminderBannerDuration = 30 * time.Second
+	reminderGrace          = time.Minute
+)
+
+// maxReminderLines is how many reminders the banner lists before "+N more"
+const maxReminderLines = 3
+
+// reminderKey identifies a reminder so it fires once: a task, its due time
+// and how long before it the reminder is
+type reminderKey struct {
+	taskID int64
+	due    int64 // Unix time, so changing the due time re-arms reminders
+	before time.Duration
+}
+
+// reminderState holds the reminders that fired and the banner showing them
+type reminderState struct {
+	fired  map[reminderKey]bool
+	banner []int64 // task IDs, newest first
+	until  time.Time
+}
+
+// checkReminders fires the reminders that are due for tasks with a due
+// time, showing them in the banner and ringing the bell. A reminder fires
+// between its time and shortly after the task's due time, so opening the
+// board shortly before a due time still shows it.
+func (m Model) checkReminders() (Model, tea.Cmd) {
+	now := m.currentTime
+	if len(m.reminders.banner) > 0 && !now.Before(m.reminders.until) {
+		m.reminders.banner = nil
+		m.reflow()
+	}
+
+	var fired []reminderKey
+	var tasks []int64
+	for _, col := range m.columns {
+		for _, task := range col.Tasks {
+			if task.Due == nil || task.Status == model.StatusDone || task.Due.AllDay {
+				continue
+			}
+			if !now.Before(task.Due.Time.Add(reminderGrace)) {
+				continue
+			}
+			firedForTask := false
+			for _, before := range m.cfg.Reminders.Before {
+				key := reminderKey{taskID: task.ID, due: task.Due.Time.Unix(), before: time.Duration(before)}
+				if m.reminders.fired[key] || now.Before(task.Due.Time.Add(-key.before)) {
+					continue
+				}
+				fired = append(fired, key)
+				firedForTask = true
+			}
+			if firedForTask {
+				tasks = append(tasks, task.ID)
+			}
+		}
+	}
+	if len(fired) == 0 {
+		return m, nil
+	}
+
+	all := make(map[reminderKey]bool, len(m.reminders.fired)+len(fired))
+	for key := range m.reminders.fired {
+		all[key] = true
+	}
+	for _, key := range fired {
+		all[key] = true
+	}
+	m.reminders.fired = all
+
+	banner := tasks
+	for _, id := range m.reminders.banner {
+		if !containsID(tasks, id) {
+			banner = append(banner, id)
+		}
+	}
+	m.reminders.banner = banner
+	m.reminders.until = now.Add(reminderBannerDuration)
+	m.reflow()
+	if m.cfg.Reminders.Bell {
+		return m.ringBell()
+	}
+	return m, nil
+}
+
+// dismissReminders hides the reminder banner
+func (m Model) dismissReminders() Model {
+	m.reminders.banner = nil
+	m.reflow()
+	return m
+}
+
+// renderReminders renders the reminder banner, or "" when it is hidden
+func (m Model) renderReminders(width int) string {
+	var lines []string
+	for _, id := range m.reminders.banner {
+		task := m.findTask(id)
+		if task == nil || task.Due == nil {
+			continue
+		}
+		if len(lines) == maxReminderLines {
+			lines = append(lines, fmt.Sprintf("   +%d more", len(m.reminders.banner)-maxReminderLines))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("⏰ %s · due %s (%s)",
+			task.Title, task.Due.Time.Local().Format("15:04"), due.Relative(*task.Due, m.currentTime)))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	style := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colorOnPrimary).
+		Background(colorWarning).
+		Padding(0, 1).
+		Width(width)
+	textWidth := width - style.GetHorizontalPadding()
+	hint := fmt.Sprintf("  %s: dismiss", m.keys.label("clear_filter"))
+	for i, line := range lines {
+		if i == 0 {
+			line = truncate(line, textWidth-lipgloss.Width(hint)) + hint
+		}
+		lines[i] = style.Render(truncate(line, textWidth))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// containsID reports whether ids contains id
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/happytaoer/cli_kanban/internal/query"
+)
+
+// Filters of the header's due counts and of the filter_overdue and
+// filter_due_today keys: unfinished tasks past due, and due later today
+const (
+	overdueFilter  = "due:overdue -status:done"
+	dueTodayFilter = "due:today -due:overdue -status:done"
+)
+
+var (
+	overdueQuery  = mustParseQuery(overdueFilter)
+	dueTodayQuery = mustParseQuery(dueTodayFilter)
+)
+
+// mustParseQuery parses a built-in filter
+func mustParseQuery(raw string) *query.Query {
+	q, err := query.Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+type searchResultsMsg struct {
+	query string
+	hits  map[string]map[int64]bool
+}
+
+// runSearch looks up the text terms of the search filter in the full-text
+// index. Until the results arrive, matchesSearch falls back to substring
+// matching.
+func (m Model) runSearch() tea.Cmd {
+	raw, filter := m.searchQuery, m.searchFilter
+	if len(filter.TextTerms()) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		hits, err := filter.TextHits(func(text string) (map[int64]bool, error) {
+			return m.db.SearchTaskIDs(text, true)
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		return searchResultsMsg{query: raw, hits: hits}
+	}
+}
+
+// setSearchQuery parses and applies a new search filter and starts its
+// full-text lookups. On a parse error the current filter is kept.
+func (m Model) setSearchQuery(raw string) (Model, tea.Cmd, error) {
+	raw = strings.TrimSpace(raw)
+	filter, err := query.Parse(raw)
+	if err != nil {
+		return m, nil, err
+	}
+	m.searchQuery = raw
+	m.searchFilter = filter
+	m.searchHits = nil
+	m.currentTask = 0
+	m.refreshVisible()
+	m.ensureTaskVisible()
+	return m, m.runSearch(), nil
+}
+
+// clearSearch removes the search filter
+func (m Model) clearSearch() Model {
+	m.searchInput.SetValue("")
+	m.searchErr = nil
+	m, _, _ = m.setSearchQuery("")
+	return m
+}
+
+// toggleDueFilter filters the board to a due filter such as overdueFilter,
+// or removes that filter when it is already applied
+func (m Model) toggleDueFilter(raw string) (Model, tea.Cmd) {
+	if m.searchQuery == raw {
+		raw = ""
+		if m.activeView != nil {
+			raw = m.activeView.Query
+		}
+	}
+	m.searchInput.SetValue(raw)
+	m.searchErr = nil
+	m, cmd, _ := m.setSearchQuery(raw)
+	return m, cmd
+}
@@ -0,0 +1,249 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// The selection is a set of task IDs that the move, delete, tags, due and
+// archive actions apply to instead of the task under the cursor. The map is
+// replaced rather than changed, since Model is passed by value.
+
+// hasSelection reports whether any task is selected
+func (m Model) hasSelection() bool {
+	return len(m.selected) > 0
+}
+
+// selectedIDs returns the selected task IDs in ascending order
+func (m Model) selectedIDs() []int64 {
+	ids := make([]int64, 0, len(m.selected))
+	for id := range m.selected {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// hiddenSelected counts selected tasks the filter or view hides
+func (m Model) hiddenSelected() int {
+	shown := 0
+	for i := range m.columns {
+		if !m.columnShown(i) {
+			continue
+		}
+		for _, idx := range m.visibleTaskIndices(i) {
+			if m.selected[m.columns[i].Tasks[idx].ID] {
+				shown++
+			}
+		}
+	}
+	return len(m.selected) - shown
+}
+
+// setSelected selects or unselects tasks
+func (m Model) setSelected(ids []int64, on bool) Model {
+	selected := make(map[int64]bool, len(m.selected)+len(ids))
+	for id := range m.selected {
+		selected[id] = true
+	}
+	for _, id := range ids {
+		if on {
+			selected[id] = true
+		} else {
+			delete(selected, id)
+		}
+	}
+	m.selected = selected
+	return m
+}
+
+// clearSelection unselects every task
+func (m Model) clearSelection() Model {
+	m.selected = nil
+	m.selectAnchor = 0
+	return m
+}
+
+// pruneSelection drops selected tasks that are no longer on the board
+func (m *Model) pruneSelection() {
+	if !m.hasSelection() {
+		return
+	}
+	var gone []int64
+	for id := range m.selected {
+		if m.findTask(id) == nil {
+			gone = append(gone, id)
+		}
+	}
+	if len(gone) > 0 {
+		*m = m.setSelected(gone, false)
+	}
+}
+
+// toggleSelect selects or unselects the task under the cursor and moves
+// down, so consecutive presses mark a run of cards
+func (m Model) toggleSelect() (Model, tea.Cmd) {
+	task := m.getCurrentTask()
+	if task == nil {
+		return m, nil
+	}
+	m = m.setSelected([]int64{task.ID}, !m.selected[task.ID])
+	m.selectAnchor = task.ID
+	if m.currentTask < len(m.visibleTaskIndices(m.currentColumn))-1 {
+		m.currentTask++
+		m.ensureTaskVisible()
+	}
+	return m, nil
+}
+
+// selectRange selects every card between the last toggled card and the
+// cursor. Without a toggled card in this column it selects the cursor card
+// and starts the range there.
+func (m Model) selectRange() (Model, tea.Cmd) {
+	task := m.getCurrentTask()
+	if task == nil {
+		return m, nil
+	}
+	indices := m.visibleTaskIndices(m.currentColumn)
+	anchor := -1
+	for i, idx := range indices {
+		if m.columns[m.currentColumn].Tasks[idx].ID == m.selectAnchor {
+			anchor = i
+		}
+	}
+	if anchor < 0 {
+		m = m.setSelected([]int64{task.ID}, true)
+		m.selectAnchor = task.ID
+		return m, nil
+	}
+
+	from, to := anchor, m.currentTask
+	if from > to {
+		from, to = to, from
+	}
+	var ids []int64
+	for _, idx := range indices[from : to+1] {
+		ids = append(ids, m.columns[m.currentColumn].Tasks[idx].ID)
+	}
+	return m.setSelected(ids, true), nil
+}
+
+// selectColumn selects every visible card in the current column, or
+// unselects them when they all are selected already
+func (m Model) selectColumn() (Model, tea.Cmd) {
+	ids := m.visibleIDs(m.currentColumn)
+	all := len(ids) > 0
+	for _, id := range ids {
+		all = all && m.selected[id]
+	}
+	return m.setSelected(ids, !all), nil
+}
+
+// selectMatching selects every card the filter and view show
+func (m Model) selectMatching() (Model, tea.Cmd) {
+	var ids []int64
+	for i := range m.columns {
+		if m.columnShown(i) {
+			ids = append(ids, m.visibleIDs(i)...)
+		}
+	}
+	return m.setSelected(ids, true), nil
+}
+
+// visibleIDs returns the IDs of the visible cards in a column
+func (m Model) visibleIDs(column int) []int64 {
+	var ids []int64
+	for _, idx := range m.visibleTaskIndices(column) {
+		ids = append(ids, m.columns[column].Tasks[idx].ID)
+	}
+	return ids
+}
+
+// moveSelected moves the selected tasks to a column. The cursor follows
+// its card when that card is one of them.
+func (m Model) moveSelected(column int) (Model, tea.Cmd) {
+	if task := m.getCurrentTask(); task != nil && m.selected[task.ID] && m.columnShown(column) {
+		m.currentColumn = column
+		m.followTaskID = task.ID
+	}
+	ids := m.selectedIDs()
+	status := m.columns[column].Status
+	return m, func() tea.Msg {
+		if err := m.db.MoveTasks(ids, status); err != nil {
+			return errMsg{err}
+		}
+		return taskUpdatedMsg{}
+	}
+}
+
+// deleteTasks deletes several tasks at once
+func (m Model) deleteTasks(ids []int64) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.DeleteTasks(ids); err != nil {
+			return errMsg{err}
+		}
+		return taskDeletedMsg{}
+	}
+}
+
+// updateSelectedTags adds and removes tags on the selected tasks
+func (m Model) updateSelectedTags(add, remove []string) tea.Cmd {
+	ids := m.selectedIDs()
+	return func() tea.Msg {
+		if err := m.db.UpdateTasksTags(ids, add, remove); err != nil {
+			return errMsg{err}
+		}
+		return tagsUpdatedMsg{}
+	}
+}
+
+// updateSelectedDue sets or clears the due date of the selected tasks
+func (m Model) updateSelectedDue(due *model.Due) tea.Cmd {
+	ids := m.selectedIDs()
+	return func() tea.Msg {
+		if err := m.db.SetTasksDue(ids, due); err != nil {
+			return errMsg{err}
+		}
+		return dueUpdatedMsg{}
+	}
+}
+
+// updateSelectedEstimate sets or clears the estimate of the selected tasks
+func (m Model) updateSelectedEstimate(estimate *float64) tea.Cmd {
+	ids := m.selectedIDs()
+	return func() tea.Msg {
+		if err := m.db.SetTasksEstimate(ids, estimate); err != nil {
+			return errMsg{err}
+		}
+		return estimateUpdatedMsg{}
+	}
+}
+
+// archiveSelected archives the selected tasks
+func (m Model) archiveSelected() tea.Cmd {
+	ids := m.selectedIDs()
+	return func() tea.Msg {
+		if err := m.db.ArchiveTasks(ids); err != nil {
+			return errMsg{err}
+		}
+		return taskArchivedMsg{}
+	}
+}
+
+// parseTagChanges splits the bulk tags input into tags to add and tags to
+// remove, which are prefixed with "-": "urgent, -later"
+func parseTagChanges(input string) (add, remove []string) {
+	for _, tag := range parseTagsInput(input) {
+		if name, ok := strings.CutPrefix(tag, "-"); ok {
+			if name = strings.TrimSpace(name); name != "" {
+				remove = append(remove, name)
+			}
+			continue
+		}
+		add = append(add, tag)
+	}
+	return add, remove
+}
@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/config"
+	"github.com/happytaoer/cli_kanban/internal/db"
+)
+
+// sprintEntry is a row of the sprint switcher
+type sprintEntry struct {
+	scope int64
+	label string
+}
+
+// sprintEntries returns the switcher rows: all tasks, backlog, then each sprint
+func (m Model) sprintEntries() []sprintEntry {
+	entries := []sprintEntry{
+		{scope: SprintScopeAll, label: "All tasks"},
+		{scope: SprintScopeBacklog, label: "Backlog (no sprint)"},
+	}
+	current := db.CurrentSprint(m.sprints, m.currentTime)
+	for _, s := range m.sprints {
+		label := fmt.Sprintf("%s  %s → %s", s.Name, s.Start.Format("Jan 2"), s.End.Format("Jan 2"))
+		switch {
+		case s.Closed():
+			label += "  (closed)"
+		case current != nil && current.ID == s.ID:
+			label += "  (current)"
+		}
+		entries = append(entries, sprintEntry{scope: s.ID, label: label})
+	}
+	return entries
+}
+
+// sprintScopeName returns a label for the active sprint scope
+func (m Model) sprintScopeName() string {
+	switch m.sprintScope {
+	case SprintScopeAll:
+		return ""
+	case SprintScopeBacklog:
+		return "Backlog"
+	}
+	for _, s := range m.sprints {
+		if s.ID == m.sprintScope {
+			return s.Name
+		}
+	}
+	return fmt.Sprintf("Sprint %d", m.sprintScope)
+}
+
+// openSprintSwitcher shows the sprint switcher with the active scope highlighted
+func (m Model) openSprintSwitcher() Model {
+	m.viewMode = ViewModeSprints
+	m.sprintCursor = 0
+	for i, e := range m.sprintEntries() {
+		if e.scope == m.sprintScope {
+			m.sprintCursor = i
+			break
+		}
+	}
+	return m
+}
+
+// handleSprintKeys handles keyboard input in the sprint switcher
+func (m Model) handleSprintKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entries := m.sprintEntries()
+
+	key := msg.String()
+	if m.keys.screen(config.ContextSprints, key) == "sprint_assign" {
+		// Assign the selected task to the highlighted sprint
+		task := m.getCurrentTask()
+		if task == nil || m.sprintCursor >= len(entries) {
+			return m, nil
+		}
+		scope := entries[m.sprintCursor].scope
+		if scope == SprintScopeAll {
+			return m, nil
+		}
+		var sprintID *int64
+		if scope != SprintScopeBacklog {
+			sprintID = &scope
+		}
+		m.followTaskID = task.ID
+		m.viewMode = ViewModeBoard
+		return m, m.assignSprint(task.ID, sprintID)
+	}
+
+	if m.keys.input(key) == "submit" {
+		if m.sprintCursor < len(entries) {
+			m.sprintScope = entries[m.sprintCursor].scope
+			m.currentTask = 0
+			m.refreshVisible()
+			m.ensureTaskVisible()
+		}
+		m.viewMode = ViewModeBoard
+		return m, nil
+	}
+
+	switch m.keys.board(key) {
+	case "up":
+		if m.sprintCursor > 0 {
+			m.sprintCursor--
+		}
+		return m, nil
+
+	case "down":
+		if m.sprintCursor < len(entries)-1 {
+			m.sprintCursor++
+		}
+		return m, nil
+	}
+
+	if m.keys.closes(key, "sprints") {
+		m.viewMode = ViewModeBoard
+	}
+	return m, nil
+}
+
+// assignSprint assigns a task to a sprint (nil moves it to the backlog)
+func (m Model) assignSprint(taskID int64, sprintID *int64) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.AssignTaskSprint(taskID, sprintID); err != nil {
+			return errMsg{err}
+		}
+		return sprintAssignedMsg{}
+	}
+}
+
+// viewSprints renders the sprint switcher
+func (m Model) viewSprints() string {
+	var b strings.Builder
+
+	title := titleStyle.Render("🏃 Sprints")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if task := m.getCurrentTask(); task != nil {
+		info := fmt.Sprintf("Selected task: %s", task.Title)
+		b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(info))
+		b.WriteString("\n\n")
+	}
+
+	for i, e := range m.sprintEntries() {
+		line := "  " + e.label
+		if e.scope == m.sprintScope {
+			line = "• " + e.label
+		}
+		if i == m.sprintCursor {
+			line = taskActiveStyle.Copy().MarginBottom(0).Width(0).Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if len(m.sprints) == 0 {
+		hint := "No sprints yet. Create one with: cli_kanban sprint create <name>"
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Render(hint))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	help := helpStyle.Render(m.keys.footer([]footerItem{
+		{[]string{"up", "down"}, "Select"},
+		{[]string{"submit"}, "Show on board"},
+		{[]string{"sprint_assign"}, "Assign selected task"},
+		{[]string{"cancel"}, "Cancel"},
+	}))
+	b.WriteString(help)
+
+	return b.String()
+}
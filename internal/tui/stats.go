@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/db"
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/report"
+)
+
+// cfdDays is how many days of history the stats view's flow chart covers
+const cfdDays = 30
+
+// statsData is the history the stats view charts are computed from
+type statsData struct {
+	timelines  map[int64]report.Timeline
+	sprint     *model.Sprint // sprint to burn down, if any
+	carryovers []model.Carryover
+}
+
+type statsLoadedMsg struct {
+	data statsData
+}
+
+// statsSprint returns the sprint the stats view burns down: the one the
+// board is scoped to, otherwise the current sprint
+func (m Model) statsSprint() *model.Sprint {
+	if m.sprintScope > 0 {
+		for i := range m.sprints {
+			if m.sprints[i].ID == m.sprintScope {
+				return &m.sprints[i]
+			}
+		}
+	}
+	return db.CurrentSprint(m.sprints, time.Now())
+}
+
+// loadStats loads status history for the stats view
+func (m Model) loadStats() tea.Cmd {
+	sprint := m.statsSprint()
+	return func() tea.Msg {
+		transitions, err := m.db.GetStatusTransitions()
+		if err != nil {
+			return errMsg{err}
+		}
+		data := statsData{timelines: report.Timelines(transitions), sprint: sprint}
+		if sprint != nil {
+			if data.carryovers, err = m.db.GetCarryovers(sprint.ID); err != nil {
+				return errMsg{err}
+			}
+		}
+		return statsLoadedMsg{data}
+	}
+}
+
+// handleStatsKeys handles keyboard input in the stats view
+func (m Model) handleStatsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if m.keys.board(key) == "refresh" {
+		return m, m.loadStats()
+	}
+	if m.keys.closes(key, "stats") {
+		m.viewMode = ViewModeBoard
+	}
+	return m, nil
+}
+
+// viewStats renders burndown and cumulative flow charts
+func (m Model) viewStats() string {
+	var b strings.Builder
+
+	title := titleStyle.Render("📈 Stats")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if m.stats.timelines == nil {
+		b.WriteString(helpStyle.Render("Loading..."))
+		return b.String()
+	}
+
+	width := m.width - 10
+	if width < 20 {
+		width = 20
+	}
+	// Two charts plus titles, axes and legends share the screen
+	chartHeight := (m.height - 16) / 2
+	if chartHeight < 4 {
+		chartHeight = 4
+	}
+
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(colorSecondary)
+	now := time.Now()
+
+	if sprint := m.stats.sprint; sprint != nil {
+		var tasks []model.Task
+		for _, col := range m.columns {
+			tasks = append(tasks, col.Tasks...)
+		}
+		scope := report.SprintScope(*sprint, tasks, m.stats.carryovers)
+		series := report.Burndown(*sprint, scope, m.stats.timelines, report.CountWeight, now)
+		heading := fmt.Sprintf("Burndown · %s (%s → %s) · remaining tasks", sprint.Name,
+			sprint.Start.Format("Jan 2"), sprint.End.Format("Jan 2"))
+		b.WriteString(sectionStyle.Render(heading))
+		b.WriteString("\n")
+		b.WriteString(report.BurndownChart(series, width, chartHeight))
+	} else {
+		b.WriteString(sectionStyle.Render("Burndown"))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Render("No sprint selected or current. Use S to pick one."))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	since := now.AddDate(0, 0, -cfdDays)
+	series := report.CumulativeFlow(m.stats.timelines, since, now, now)
+	b.WriteString(sectionStyle.Render(fmt.Sprintf("Cumulative flow · last %d days", cfdDays)))
+	b.WriteString("\n")
+	b.WriteString(report.CFDChart(series, report.DefaultBands(), width, chartHeight))
+	b.WriteString("\n")
+
+	help := helpStyle.Render(m.keys.footer([]footerItem{
+		{[]string{"refresh"}, "Refresh"},
+		{[]string{"cancel"}, "Back to board"},
+	}))
+	b.WriteString(help)
+
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/theme"
+)
+
+// activeTheme is the theme the package styles were built from
+var activeTheme theme.Theme
+
+func init() {
+	applyTheme(theme.Resolve("dark", nil))
+}
+
+// applyTheme sets the package colors and rebuilds the styles from a theme
+func applyTheme(t theme.Theme) {
+	activeTheme = t
+
+	colorPrimary = t.Primary
+	colorSecondary = t.Secondary
+	colorSuccess = t.Success
+	colorDanger = t.Danger
+	colorWarning = t.Warning
+	colorMuted = t.Muted
+	colorBorder = t.Border
+	colorText = t.Text
+	colorOnPrimary = t.OnPrimary
+
+	titleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colorPrimary).
+		MarginBottom(1)
+
+	columnStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Padding(1, 2).
+		Width(30)
+
+	columnTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colorSecondary).
+		MarginBottom(1)
+
+	taskStyle = lipgloss.NewStyle().
+		Padding(0, 1).
+		MarginBottom(1).
+		Width(26)
+
+	taskActiveStyle = lipgloss.NewStyle().
+		Padding(0, 1).
+		MarginBottom(1).
+		Width(26).
+		Background(colorPrimary).
+		Foreground(colorOnPrimary).
+		Bold(true)
+
+	helpStyle = lipgloss.NewStyle().
+		Foreground(colorMuted)
+
+	footerStyle = lipgloss.NewStyle().
+		BorderTop(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(colorBorder).
+		Foreground(colorMuted).
+		PaddingTop(1)
+
+	inputStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(60)
+
+	highlightStyle = lipgloss.NewStyle().
+		Foreground(t.HighlightText).
+		Background(t.HighlightBackground)
+
+	errorStyle = lipgloss.NewStyle().
+		Foreground(colorDanger).
+		Bold(true)
+
+	statsStyle = lipgloss.NewStyle().
+		Foreground(colorMuted).
+		MarginBottom(1)
+
+	if t.Mono {
+		// Without colors, mark the selection and matches with attributes
+		taskActiveStyle = taskActiveStyle.Reverse(true)
+		highlightStyle = highlightStyle.Reverse(true)
+	}
+}
+
+// columnColor returns the title and border color of a column
+func columnColor(status model.TaskStatus) lipgloss.TerminalColor {
+	if c, ok := activeTheme.Columns[status]; ok {
+		return c
+	}
+	return colorMuted
+}
+
+// tagStyle returns the chip style of a tag, colored by the tag name's hash
+func tagStyle(tag string) lipgloss.Style {
+	style := lipgloss.NewStyle().Foreground(activeTheme.TagText)
+	if activeTheme.Mono {
+		return style.Reverse(true)
+	}
+	hash := 0
+	for _, c := range tag {
+		hash += int(c)
+	}
+	return style.Background(activeTheme.Tags[hash%len(activeTheme.Tags)])
+}
@@ -2,11 +2,14 @@ package tui
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/happytaoer/cli_kanban/internal/due"
 	"github.com/happytaoer/cli_kanban/internal/model"
 )
 
@@ -35,15 +38,55 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport.Width = msg.Width
 			m.viewport.Height = vpHeight
 		}
+		// Column widths and the cards that fit changed: re-flow the board
+		m.reflow()
 		return m, nil
 
 	case clockTickMsg:
 		m.currentTime = time.Time(msg)
-		return m, clockTickCmd()
+		m, cmd = m.tickFocus()
+		var reminderCmd tea.Cmd
+		m, reminderCmd = m.checkReminders()
+		return m, tea.Batch(clockTickCmd(), cmd, reminderCmd)
 
 	case tasksLoadedMsg:
 		m.organizeTasks(msg.tasks)
 		m.err = nil
+		// Edits may change which tasks match a keyword search
+		return m, m.runSearch()
+
+	case searchResultsMsg:
+		if msg.query == m.searchQuery {
+			m.searchHits = msg.hits
+			m.refreshVisible()
+			m.ensureTaskVisible()
+		}
+		return m, nil
+
+	case sprintsLoadedMsg:
+		m.sprints = msg.sprints
+		return m, nil
+
+	case viewsLoadedMsg:
+		m.views = msg.views
+		if m.viewCursor > len(m.views) {
+			m.viewCursor = len(m.views)
+		}
+		return m, nil
+
+	case viewSavedMsg:
+		m.activeView = msg.view
+		m.sortColumns()
+		return m, m.loadViews()
+
+	case viewDeletedMsg:
+		return m, m.loadViews()
+
+	case sprintAssignedMsg:
+		return m, m.loadTasks()
+
+	case statsLoadedMsg:
+		m.stats = msg.data
 		return m, nil
 
 	case taskCreatedMsg:
@@ -64,16 +107,47 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case dueUpdatedMsg:
 		return m, m.loadTasks()
 
+	case timerUpdatedMsg:
+		return m, m.loadTasks()
+
+	case pomodoroLoggedMsg:
+		return m, m.loadTasks()
+
+	case bellDoneMsg:
+		// A later bell keeps ringing until its own message
+		if msg.bell == m.bells {
+			m.ringing = false
+		}
+		return m, nil
+
+	case estimateUpdatedMsg:
+		return m, m.loadTasks()
+
+	case taskArchivedMsg:
+		return m, m.loadTasks()
+
+	case editorFinishedMsg:
+		return m.finishEdit(msg)
+
+	case detailLoadedMsg:
+		if msg.taskID == m.detail.taskID {
+			m.detail.notes = msg.entries
+		}
+		return m, nil
+
 	case errMsg:
 		m.err = msg.err
 		return m, nil
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
 	}
 
 	// Handle text input updates
-	if m.viewMode == ViewModeAddTask || m.viewMode == ViewModeEditTask || m.viewMode == ViewModeEditTags {
+	if m.viewMode == ViewModeAddTask || m.viewMode == ViewModeEditTask || m.viewMode == ViewModeEditTags || m.viewMode == ViewModeTimerNote || m.viewMode == ViewModeEditEstimate || m.viewMode == ViewModeSaveView {
 		m.textInput, cmd = m.textInput.Update(msg)
 		return m, cmd
 	}
@@ -84,6 +158,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Handle palette input updates
+	if m.viewMode == ViewModePalette {
+		m.paletteInput, cmd = m.paletteInput.Update(msg)
+		return m, cmd
+	}
+
+	// Handle finder input updates
+	if m.viewMode == ViewModeFinder {
+		m.finderInput, cmd = m.finderInput.Update(msg)
+		return m, cmd
+	}
+
 	// Handle search input updates
 	if m.viewMode == ViewModeSearch {
 		m.searchInput, cmd = m.searchInput.Update(msg)
@@ -101,25 +187,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleKeyPress handles keyboard input
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Global keys
-	switch msg.String() {
-	case "ctrl+c", "q":
-		if m.viewMode == ViewModeBoard {
-			return m, tea.Quit
-		}
-	case "esc":
-		if m.viewMode != ViewModeBoard {
-			m.viewMode = ViewModeBoard
-			m.textInput.SetValue("")
-			return m, nil
+	// The cancel key leaves any other mode; modes with their own cancel
+	// behavior handle it themselves
+	if m.viewMode != ViewModeBoard && m.keys.input(msg.String()) == "cancel" {
+		switch m.viewMode {
+		case ViewModeFocus:
+			return m.leaveFocus(), nil
+		case ViewModeSearch:
+			return m.handleSearchKeys(msg)
+		case ViewModePalette:
+			return m.handlePaletteKeys(msg)
+		case ViewModeCalendar:
+			return m.handleCalendarKeys(msg)
 		}
-		// If in board mode with active search, clear search first
-		if m.searchQuery != "" {
-			m.searchQuery = ""
-			m.searchInput.SetValue("")
-			return m, nil
-		}
-		return m, tea.Quit
+		m.viewMode = ViewModeBoard
+		m.textInput.SetValue("")
+		return m, nil
 	}
 
 	// Mode-specific keys
@@ -142,6 +225,28 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleHelpKeys(msg)
 	case ViewModeSearch:
 		return m.handleSearchKeys(msg)
+	case ViewModeTimerNote:
+		return m.handleTimerNoteKeys(msg)
+	case ViewModeFocus:
+		return m.handleFocusKeys(msg)
+	case ViewModeEditEstimate:
+		return m.handleEditEstimateKeys(msg)
+	case ViewModeSprints:
+		return m.handleSprintKeys(msg)
+	case ViewModeStats:
+		return m.handleStatsKeys(msg)
+	case ViewModeViews:
+		return m.handleViewsKeys(msg)
+	case ViewModeSaveView:
+		return m.handleSaveViewKeys(msg)
+	case ViewModeFinder:
+		return m.handleFinderKeys(msg)
+	case ViewModePalette:
+		return m.handlePaletteKeys(msg)
+	case ViewModeDetail:
+		return m.handleDetailKeys(msg)
+	case ViewModeCalendar:
+		return m.handleCalendarKeys(msg)
 	}
 
 	return m, nil
@@ -149,29 +254,37 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleBoardKeys handles keyboard input in board view mode
 func (m Model) handleBoardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "left", "h":
-		if m.currentColumn > 0 {
-			m.currentColumn--
-			m.currentTask = 0
+	switch m.keys.board(msg.String()) {
+	case "left":
+		for i := m.currentColumn - 1; i >= 0; i-- {
+			if m.columnShown(i) {
+				m.currentColumn = i
+				m.currentTask = 0
+				break
+			}
 		}
+		m.ensureTaskVisible()
 		return m, nil
 
-	case "right", "l":
-		if m.currentColumn < len(m.columns)-1 {
-			m.currentColumn++
-			m.currentTask = 0
+	case "right":
+		for i := m.currentColumn + 1; i < len(m.columns); i++ {
+			if m.columnShown(i) {
+				m.currentColumn = i
+				m.currentTask = 0
+				break
+			}
 		}
+		m.ensureTaskVisible()
 		return m, nil
 
-	case "up", "k":
+	case "up":
 		if m.currentTask > 0 {
 			m.currentTask--
 			m.ensureTaskVisible()
 		}
 		return m, nil
 
-	case "down", "j":
+	case "down":
 		col := m.columns[m.currentColumn]
 		if m.currentTask < len(col.Tasks)-1 {
 			m.currentTask++
@@ -179,95 +292,121 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "a":
-		m.viewMode = ViewModeAddTask
-		m.textInput.SetValue("")
-		m.textInput.Focus()
-		return m, nil
+	case "add":
+		return m.startAddTask()
 
-	case "e", "enter":
-		task := m.getCurrentTask()
-		if task != nil {
-			m.viewMode = ViewModeEditTask
-			m.textInput.SetValue(task.Title)
-			m.textInput.Focus()
-		}
-		return m, nil
+	case "edit":
+		return m.startEditTitle()
 
-	case "d", "delete":
-		task := m.getCurrentTask()
-		if task != nil {
-			m.pendingDeleteID = task.ID
-			m.viewMode = ViewModeConfirmDelete
-		}
-		return m, nil
+	case "delete":
+		return m.confirmDeleteTask()
 
-	case "m":
-		task := m.getCurrentTask()
-		if task != nil {
-			nextColumn := (m.currentColumn + 1) % len(m.columns)
-			m.currentColumn = nextColumn
-			m.followTaskID = task.ID
-			return m, m.moveTask(task, nextColumn)
-		}
-		return m, nil
+	case "move":
+		return m.moveToNextColumn()
 
-	case "i":
-		task := m.getCurrentTask()
-		if task != nil {
-			m.viewMode = ViewModeEditDescription
-			// Expand textarea to fit available width when editing description
-			textareaWidth := m.width - 4
-			if textareaWidth < 40 {
-				textareaWidth = 40
-			}
-			m.textArea.SetWidth(textareaWidth)
-			// Expand textarea height based on available screen height
-			availableHeight := m.height - 8 // title, info, spacing
-			if availableHeight < 6 {
-				availableHeight = 6
-			}
-			m.textArea.SetHeight(availableHeight)
-			m.textArea.SetValue(task.Description)
-			m.textArea.Focus()
-		}
-		return m, nil
+	case "description":
+		return m.startEditDescription()
 
-	case "t":
-		task := m.getCurrentTask()
-		if task != nil {
-			m.viewMode = ViewModeEditTags
-			m.textInput.SetValue(strings.Join(task.Tags, ", "))
-			m.textInput.Focus()
-		}
-		return m, nil
+	case "tags":
+		return m.startEditTags()
+
+	case "due":
+		return m.startEditDue()
 
-	case "u":
+	case "estimate":
+		return m.startEditEstimate()
+
+	case "timer":
+		return m.toggleTimer()
+
+	case "focus":
 		task := m.getCurrentTask()
 		if task != nil {
-			m.viewMode = ViewModeEditDue
-			if task.Due != nil {
-				m.dueInput.SetValue(task.Due.Format("2006-01-02"))
-			} else {
-				m.dueInput.SetValue("")
-			}
-			m.dueInput.Focus()
+			return m.enterFocus(task), nil
 		}
 		return m, nil
 
-	case "?":
+	case "archive":
+		return m.archiveSelectedTask()
+
+	case "sprints":
+		return m.openSprintSwitcher(), m.loadSprints()
+
+	case "editor":
+		return m.openEditor()
+
+	case "detail":
+		return m.openDetail()
+
+	case "detail_pane":
+		return m.toggleDetailPane()
+
+	case "calendar":
+		return m.openCalendar()
+
+	case "stats":
+		return m.openStats()
+
+	case "find":
+		return m.openFinder(), nil
+
+	case "palette":
+		return m.openPalette(), nil
+
+	case "views":
+		return m.openViewPicker()
+
+	case "help":
 		m.viewMode = ViewModeHelp
 		return m, nil
 
-	case "/":
-		m.viewMode = ViewModeSearch
-		m.searchInput.SetValue(m.searchQuery)
-		m.searchInput.Focus()
-		return m, nil
+	case "search":
+		return m.openSearch(), nil
 
-	case "f5":
-		// Refresh: reload tasks from database
-		return m, m.loadTasks()
+	case "filter_overdue":
+		return m.toggleDueFilter(overdueFilter)
+
+	case "filter_due_today":
+		return m.toggleDueFilter(dueTodayFilter)
+
+	case "refresh":
+		return m.refresh()
+
+	case "select":
+		return m.toggleSelect()
+
+	case "select_range":
+		return m.selectRange()
+
+	case "select_column":
+		return m.selectColumn()
+
+	case "select_all":
+		return m.selectMatching()
+
+	case "clear_filter":
+		// Dismiss reminders and clear the selection, then leave the active
+		// view or search; quit on an unfiltered board
+		if len(m.reminders.banner) > 0 {
+			return m.dismissReminders(), nil
+		}
+		if m.hasSelection() {
+			return m.clearSelection(), nil
+		}
+		if m.activeView != nil {
+			return m.applyView(nil)
+		}
+		if m.searchQuery != "" {
+			return m.clearSearch(), nil
+		}
+		return m, tea.Quit
+
+	case "quit":
+		return m, tea.Quit
+	}
+
+	if n := quickViewNumber(msg.String()); n > 0 {
+		return m.applyQuickView(n)
 	}
 
 	return m, nil
@@ -275,29 +414,28 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleEditDueKeys handles keyboard input in edit due mode
 func (m Model) handleEditDueKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	switch m.keys.input(msg.String()) {
+	case "submit":
 		dueStr := strings.TrimSpace(m.dueInput.Value())
 		task := m.getCurrentTask()
-		if task != nil {
-			var due *time.Time
-			if dueStr != "" {
-				// Try parsing the date
-				if t, err := time.Parse("2006-01-02", dueStr); err == nil {
-					due = &t
-				} else {
-					// Invalid format, show error but stay in edit mode
-					m.err = fmt.Errorf("invalid date format, use YYYY-MM-DD")
-					return m, nil
-				}
+		if task != nil || m.hasSelection() {
+			date, err := due.Parse(dueStr, m.currentTime)
+			if err != nil {
+				// Stay in edit mode; the preview shows the error
+				m.err = err
+				return m, nil
 			}
+			m.err = nil
 			m.viewMode = ViewModeBoard
 			m.dueInput.SetValue("")
-			return m, m.updateDue(task.ID, due)
+			if m.hasSelection() {
+				return m, m.updateSelectedDue(date)
+			}
+			return m, m.updateDue(task.ID, date)
 		}
 		return m, nil
 
-	case "esc":
+	case "cancel":
 		m.viewMode = ViewModeBoard
 		m.dueInput.SetValue("")
 		return m, nil
@@ -308,31 +446,82 @@ func (m Model) handleEditDueKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// handleSearchKeys handles keyboard input in search mode
-func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
-		m.searchQuery = strings.ToLower(strings.TrimSpace(m.searchInput.Value()))
-		m.viewMode = ViewModeBoard
-		m.currentTask = 0 // reset task selection
+// handleEditEstimateKeys handles keyboard input in edit estimate mode
+func (m Model) handleEditEstimateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.input(msg.String()) {
+	case "submit":
+		estimateStr := strings.TrimSpace(m.textInput.Value())
+		task := m.getCurrentTask()
+		if task != nil || m.hasSelection() {
+			var estimate *float64
+			if estimateStr != "" {
+				v, err := strconv.ParseFloat(estimateStr, 64)
+				if err != nil || v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+					// Invalid number, show error but stay in edit mode
+					m.err = fmt.Errorf("invalid estimate, use a non-negative finite number")
+					return m, nil
+				}
+				estimate = &v
+			}
+			m.err = nil
+			m.viewMode = ViewModeBoard
+			m.textInput.SetValue("")
+			if m.hasSelection() {
+				return m, m.updateSelectedEstimate(estimate)
+			}
+			return m, m.updateEstimate(task.ID, estimate)
+		}
 		return m, nil
 
-	case "esc":
-		m.searchInput.SetValue("")
-		m.searchQuery = ""
+	case "cancel":
 		m.viewMode = ViewModeBoard
+		m.textInput.SetValue("")
 		return m, nil
 	}
 
 	var cmd tea.Cmd
-	m.searchInput, cmd = m.searchInput.Update(msg)
+	m.textInput, cmd = m.textInput.Update(msg)
 	return m, cmd
 }
 
+// handleSearchKeys handles keyboard input in search mode
+func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.input(msg.String()) {
+	case "submit":
+		if m.searchErr != nil {
+			// Stay in the search input with the error shown
+			return m, nil
+		}
+		m.viewMode = ViewModeBoard
+		return m, nil
+
+	case "cancel":
+		// Restore the filter that was active before the search input opened
+		m.viewMode = ViewModeBoard
+		m.searchInput.SetValue(m.prevSearchQuery)
+		m.searchErr = nil
+		m, cmd, _ := m.setSearchQuery(m.prevSearchQuery)
+		return m, cmd
+	}
+
+	var inputCmd tea.Cmd
+	m.searchInput, inputCmd = m.searchInput.Update(msg)
+
+	// Filter live; while the input does not parse, keep the last valid filter
+	value := m.searchInput.Value()
+	if strings.TrimSpace(value) == m.searchQuery {
+		m.searchErr = nil
+		return m, inputCmd
+	}
+	m, searchCmd, err := m.setSearchQuery(value)
+	m.searchErr = err
+	return m, tea.Batch(inputCmd, searchCmd)
+}
+
 // handleAddTaskKeys handles keyboard input in add task mode
 func (m Model) handleAddTaskKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	switch m.keys.input(msg.String()) {
+	case "submit":
 		title := m.textInput.Value()
 		if title != "" {
 			status := m.columns[m.currentColumn].Status
@@ -342,7 +531,7 @@ func (m Model) handleAddTaskKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "esc":
+	case "cancel":
 		m.viewMode = ViewModeBoard
 		m.textInput.SetValue("")
 		return m, nil
@@ -355,8 +544,8 @@ func (m Model) handleAddTaskKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleEditTaskKeys handles keyboard input in edit task mode
 func (m Model) handleEditTaskKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	switch m.keys.input(msg.String()) {
+	case "submit":
 		title := m.textInput.Value()
 		task := m.getCurrentTask()
 		if title != "" && task != nil {
@@ -366,7 +555,7 @@ func (m Model) handleEditTaskKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "esc":
+	case "cancel":
 		m.viewMode = ViewModeBoard
 		m.textInput.SetValue("")
 		return m, nil
@@ -379,8 +568,8 @@ func (m Model) handleEditTaskKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleEditDescriptionKeys handles keyboard input in edit description mode
 func (m Model) handleEditDescriptionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+s":
+	switch m.keys.input(msg.String()) {
+	case "save_description":
 		description := m.textArea.Value()
 		task := m.getCurrentTask()
 		if task != nil {
@@ -390,7 +579,7 @@ func (m Model) handleEditDescriptionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "esc":
+	case "cancel":
 		m.viewMode = ViewModeBoard
 		m.textArea.SetValue("")
 		return m, nil
@@ -403,9 +592,14 @@ func (m Model) handleEditDescriptionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleEditTagsKeys handles keyboard input in edit tags mode
 func (m Model) handleEditTagsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	switch m.keys.input(msg.String()) {
+	case "submit":
 		tagsStr := m.textInput.Value()
+		if m.hasSelection() {
+			m.viewMode = ViewModeBoard
+			m.textInput.SetValue("")
+			return m, m.updateSelectedTags(parseTagChanges(tagsStr))
+		}
 		task := m.getCurrentTask()
 		if task != nil {
 			tags := parseTagsInput(tagsStr)
@@ -415,7 +609,32 @@ func (m Model) handleEditTagsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "esc":
+	case "cancel":
+		m.viewMode = ViewModeBoard
+		m.textInput.SetValue("")
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// handleTimerNoteKeys handles keyboard input when stopping a timer
+func (m Model) handleTimerNoteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.input(msg.String()) {
+	case "submit":
+		note := strings.TrimSpace(m.textInput.Value())
+		task := m.getCurrentTask()
+		if task != nil {
+			m.viewMode = ViewModeBoard
+			m.textInput.SetValue("")
+			return m, m.stopTimer(task.ID, note)
+		}
+		return m, nil
+
+	case "cancel":
+		// Keep the timer running
 		m.viewMode = ViewModeBoard
 		m.textInput.SetValue("")
 		return m, nil
@@ -441,15 +660,18 @@ func parseTagsInput(input string) []string {
 
 // handleConfirmDeleteKeys handles keyboard input in delete confirmation mode
 func (m Model) handleConfirmDeleteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
-		id := m.pendingDeleteID
-		m.pendingDeleteID = 0
+	switch m.keys.confirm(msg.String()) {
+	case "confirm":
+		ids := m.pendingDeleteIDs
+		m.pendingDeleteIDs = nil
 		m.viewMode = ViewModeBoard
-		return m, m.deleteTask(id)
+		if len(ids) == 1 {
+			return m, m.deleteTask(ids[0])
+		}
+		return m, m.deleteTasks(ids)
 
-	case "n", "N", "esc":
-		m.pendingDeleteID = 0
+	case "deny":
+		m.pendingDeleteIDs = nil
 		m.viewMode = ViewModeBoard
 		return m, nil
 	}
@@ -463,10 +685,15 @@ func (m Model) handleHelpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// createTask creates a new task
+// createTask creates a new task, assigning it to the sprint shown on the board
 func (m Model) createTask(title string, status model.TaskStatus) tea.Cmd {
+	var sprintID *int64
+	if m.sprintScope > 0 {
+		scope := m.sprintScope
+		sprintID = &scope
+	}
 	return func() tea.Msg {
-		task, err := m.db.CreateTask(title, status)
+		task, err := m.db.CreateTask(title, status, sprintID)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -519,7 +746,7 @@ func (m Model) updateTags(id int64, tags []string) tea.Cmd {
 }
 
 // updateDue updates a task's due date
-func (m Model) updateDue(id int64, due *time.Time) tea.Cmd {
+func (m Model) updateDue(id int64, due *model.Due) tea.Cmd {
 	return func() tea.Msg {
 		err := m.db.UpdateTaskDue(id, due)
 		if err != nil {
@@ -529,6 +756,37 @@ func (m Model) updateDue(id int64, due *time.Time) tea.Cmd {
 	}
 }
 
+// updateEstimate updates a task's estimate
+func (m Model) updateEstimate(id int64, estimate *float64) tea.Cmd {
+	return func() tea.Msg {
+		err := m.db.UpdateTaskEstimate(id, estimate)
+		if err != nil {
+			return errMsg{err}
+		}
+		return estimateUpdatedMsg{}
+	}
+}
+
+// startTimer starts tracking time on a task
+func (m Model) startTimer(id int64) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := m.db.StartTimer(id); err != nil {
+			return errMsg{err}
+		}
+		return timerUpdatedMsg{}
+	}
+}
+
+// stopTimer stops tracking time on a task
+func (m Model) stopTimer(id int64, note string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := m.db.StopTimer(id, note); err != nil {
+			return errMsg{err}
+		}
+		return timerUpdatedMsg{}
+	}
+}
+
 // moveTask moves a task to the target column
 func (m Model) moveTask(task *model.Task, targetColumn int) tea.Cmd {
 	newStatus := m.columns[targetColumn].Status
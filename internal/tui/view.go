@@ -4,78 +4,51 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/due"
 	"github.com/happytaoer/cli_kanban/internal/model"
+	"github.com/happytaoer/cli_kanban/internal/query"
 )
 
 var (
-	// Colors
-	colorPrimary    = lipgloss.Color("#7C3AED")
-	colorSecondary  = lipgloss.Color("#A78BFA")
-	colorInProgress = lipgloss.Color("#3B82F6")
-	colorSuccess    = lipgloss.Color("#10B981")
-	colorDanger     = lipgloss.Color("#EF4444")
-	colorMuted      = lipgloss.Color("#6B7280")
-	colorBorder     = lipgloss.Color("#374151")
-
-	// Styles
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(colorPrimary).
-			MarginBottom(1)
-
-	columnStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colorBorder).
-			Padding(1, 2).
-			Width(30)
-
-	columnTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(colorSecondary).
-				MarginBottom(1)
-
-	taskStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			MarginBottom(1).
-			Width(26)
-
-	taskActiveStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			MarginBottom(1).
-			Width(26).
-			Background(colorPrimary).
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Bold(true)
-
-	helpStyle = lipgloss.NewStyle().
-			Foreground(colorMuted)
-
-	footerStyle = lipgloss.NewStyle().
-			BorderTop(true).
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(colorBorder).
-			Foreground(colorMuted).
-			PaddingTop(1)
-
-	inputStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(colorPrimary).
-			Padding(1, 2).
-			Width(60)
-
-	errorStyle = lipgloss.NewStyle().
-			Foreground(colorDanger).
-			Bold(true)
-
-	statsStyle = lipgloss.NewStyle().
-			Foreground(colorMuted).
-			MarginBottom(1)
+	// Colors, set by applyTheme
+	colorPrimary   lipgloss.TerminalColor
+	colorSecondary lipgloss.TerminalColor
+	colorSuccess   lipgloss.TerminalColor
+	colorDanger    lipgloss.TerminalColor
+	colorWarning   lipgloss.TerminalColor
+	colorMuted     lipgloss.TerminalColor
+	colorBorder    lipgloss.TerminalColor
+	colorText      lipgloss.TerminalColor
+	colorOnPrimary lipgloss.TerminalColor
+
+	// Styles, built from the theme by applyTheme
+	titleStyle       lipgloss.Style
+	columnStyle      lipgloss.Style
+	columnTitleStyle lipgloss.Style
+	taskStyle        lipgloss.Style
+	taskActiveStyle  lipgloss.Style
+	helpStyle        lipgloss.Style
+	footerStyle      lipgloss.Style
+	inputStyle       lipgloss.Style
+	highlightStyle   lipgloss.Style
+	errorStyle       lipgloss.Style
+	statsStyle       lipgloss.Style
 )
 
 // View renders the TUI
 func (m Model) View() string {
+	// The bell leads the first line, which is only redrawn when it changes
+	if m.ringing {
+		return "\a" + m.viewScreen()
+	}
+	return m.viewScreen()
+}
+
+// viewScreen renders the screen of the current mode
+func (m Model) viewScreen() string {
 	switch m.viewMode {
 	case ViewModeAddTask:
 		return m.viewAddTask()
@@ -87,10 +60,32 @@ func (m Model) View() string {
 		return m.viewEditTags()
 	case ViewModeEditDue:
 		return m.viewEditDue()
+	case ViewModeTimerNote:
+		return m.viewTimerNote()
+	case ViewModeFocus:
+		return m.viewFocus()
+	case ViewModeEditEstimate:
+		return m.viewEditEstimate()
+	case ViewModeSprints:
+		return m.viewSprints()
+	case ViewModeStats:
+		return m.viewStats()
+	case ViewModeViews:
+		return m.viewViews()
+	case ViewModeSaveView:
+		return m.viewSaveView()
+	case ViewModeFinder:
+		return m.viewFinder()
+	case ViewModePalette:
+		return m.viewPalette()
 	case ViewModeConfirmDelete:
 		return m.viewConfirmDelete()
 	case ViewModeHelp:
 		return m.viewHelp()
+	case ViewModeDetail:
+		return m.viewDetail()
+	case ViewModeCalendar:
+		return m.viewCalendar()
 	default:
 		return m.viewBoard()
 	}
@@ -98,30 +93,20 @@ func (m Model) View() string {
 
 // viewBoard renders the kanban board
 func (m Model) viewBoard() string {
-	// Header: Title + Statistics on same line
-	title := titleStyle.Render("📋 Kanban Board")
-	stats := m.renderStats()
-	headerWidth := m.width
-	if headerWidth <= 0 {
-		headerWidth = 80
-	}
-	// Place title on left, stats on right
-	spacerWidth := headerWidth - lipgloss.Width(title) - lipgloss.Width(stats)
-	if spacerWidth < 0 {
-		spacerWidth = 1
-	}
-	header := lipgloss.JoinHorizontal(lipgloss.Center,
-		title,
-		lipgloss.NewStyle().Width(spacerWidth).Render(""),
-		stats,
-	)
+	header := m.renderHeader()
+	footer := m.renderFooter()
 
 	// Columns content for viewport
-	columns := make([]string, len(m.columns))
-	for i, col := range m.columns {
-		columns[i] = m.renderColumn(i, col)
+	visible, hiddenLeft, hiddenRight := m.visibleColumns()
+	height := m.boardHeightBetween(header, footer)
+	var columns []string
+	for _, i := range visible {
+		columns = append(columns, m.renderColumn(i, m.columns[i], height))
 	}
 	columnsView := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+	if hiddenLeft > 0 || hiddenRight > 0 {
+		columnsView = m.renderScrollHint(hiddenLeft, hiddenRight) + "\n" + columnsView
+	}
 
 	// Error message appended to columns if present
 	if m.err != nil {
@@ -129,9 +114,58 @@ func (m Model) viewBoard() string {
 	}
 
 	// Set viewport content and render
+	m.viewport.Width = m.width
+	m.viewport.Height = m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if m.viewport.Height < 1 {
+		m.viewport.Height = 1
+	}
+	if paneWidth := m.detailPaneWidth(); paneWidth > 0 {
+		columnsView = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.PlaceHorizontal(m.boardWidth(), lipgloss.Left, columnsView),
+			m.renderDetailPane(paneWidth, m.viewport.Height),
+		)
+	}
 	m.viewport.SetContent(columnsView)
 
-	// Footer with help text or search input (fixed at bottom)
+	// Combine: header + viewport + footer
+	return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), footer)
+}
+
+// renderHeader renders the board title with the task counts on the right
+func (m Model) renderHeader() string {
+	boardTitle := "📋 Kanban Board"
+	if name := m.sprintScopeName(); name != "" {
+		boardTitle += " · " + name
+	}
+	title := titleStyle.Render(boardTitle)
+	stats := m.renderStats()
+	headerWidth := m.width
+	if headerWidth <= 0 {
+		headerWidth = 80
+	}
+	// Place title on left, stats on right, or below it when too narrow
+	spacerWidth := headerWidth - lipgloss.Width(title) - lipgloss.Width(stats)
+	var header string
+	if spacerWidth < 1 {
+		fit := lipgloss.NewStyle().MaxWidth(headerWidth)
+		header = fit.Render(titleStyle.Copy().MarginBottom(0).Render(boardTitle)) + "\n" +
+			fit.Render(stats)
+	} else {
+		header = lipgloss.JoinHorizontal(lipgloss.Center,
+			title,
+			lipgloss.NewStyle().Width(spacerWidth).Render(""),
+			stats,
+		)
+	}
+	// Reminders that fired show in a banner under the title
+	if banner := m.renderReminders(headerWidth); banner != "" {
+		header += "\n" + banner
+	}
+	return header
+}
+
+// renderFooter renders the help text, or the search input while searching
+func (m Model) renderFooter() string {
 	var footerContent string
 	helpWidth := m.width
 	if helpWidth <= 0 {
@@ -142,59 +176,165 @@ func (m Model) viewBoard() string {
 		// Show search input in footer
 		searchLabel := lipgloss.NewStyle().Bold(true).Render("Search: ")
 		footerContent = searchLabel + m.searchInput.View()
-	} else if m.searchQuery != "" {
-		// Show active search filter
-		searchInfo := lipgloss.NewStyle().Render(fmt.Sprintf("Filter: \"%s\"", m.searchQuery))
-		helpText := "/ : Search | Esc: Clear filter | F5: Refresh | ← → : Navigate | a: Add | e: Edit | ?: Help | q: Quit"
-		footerContent = searchInfo + "  |  " + helpText
+		if m.searchQuery != "" {
+			footerContent += "  " + lipgloss.NewStyle().Foreground(colorMuted).Render(m.matchCountText())
+		}
+		if m.searchErr != nil {
+			footerContent += "  " + errorStyle.Render(m.searchErr.Error())
+		}
+	} else if m.hasSelection() {
+		// Bulk actions apply to the selection
+		selection := fmt.Sprintf("%d selected", len(m.selected))
+		if hidden := m.hiddenSelected(); hidden > 0 {
+			selection += fmt.Sprintf(" (%d hidden by the filter)", hidden)
+		}
+		footerContent = lipgloss.NewStyle().Bold(true).Render(selection) + "  |  " + m.keys.footer(selectionFooter)
+	} else if m.searchQuery != "" || m.activeView != nil {
+		// Show active view and search filter
+		searchInfo := fmt.Sprintf("Filter: \"%s\" (%s)", m.searchQuery, m.matchCountText())
+		if m.activeView != nil {
+			searchInfo = fmt.Sprintf("View: %s  %s", m.activeView.Name, searchInfo)
+		}
+		footerContent = searchInfo + "  |  " + m.keys.footer(filterFooter)
 	} else {
 		// Normal help text
-		footerContent = "← → : Navigate | a: Add | e: Edit | i: Desc | t: Tags | u: Due | d: Del | m: Move | / : Search | F5: Refresh | ?: Help | q: Quit"
+		footerContent = m.keys.footer(boardFooter)
 	}
 
 	helpContent := lipgloss.PlaceHorizontal(helpWidth, lipgloss.Left, footerContent)
-	footer := footerStyle.Width(helpWidth).Render(helpContent)
+	return footerStyle.Width(helpWidth).Render(helpContent)
+}
 
-	// Combine: header + viewport + footer
-	return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), footer)
+// renderScrollHint shows how many columns are off screen on either side
+func (m Model) renderScrollHint(hiddenLeft, hiddenRight int) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(colorMuted)
+	left, right := "", ""
+	if hiddenLeft > 0 {
+		left = fmt.Sprintf("◀ %d more", hiddenLeft)
+	}
+	if hiddenRight > 0 {
+		right = fmt.Sprintf("%d more ▶", hiddenRight)
+	}
+	width := m.boardWidth()
+	if width <= 0 {
+		width = 80
+	}
+	gap := width - lipgloss.Width(left) - lipgloss.Width(right)
+	if gap < 1 {
+		gap = 1
+	}
+	return mutedStyle.Render(left + strings.Repeat(" ", gap) + right)
+}
+
+// matchCountText describes how many tasks pass the current filters
+func (m Model) matchCountText() string {
+	count := 0
+	for i := range m.columns {
+		if m.columnShown(i) {
+			count += len(m.visibleTaskIndices(i))
+		}
+	}
+	if count == 1 {
+		return "1 match"
+	}
+	return fmt.Sprintf("%d matches", count)
 }
 
 // renderStats renders the statistics bar
 func (m Model) renderStats() string {
 	var parts []string
-	for _, col := range m.columns {
-		labelStyle := lipgloss.NewStyle().Foreground(colorMuted)
-		switch col.Status {
-		case model.StatusInProgress:
-			labelStyle = labelStyle.Copy().Foreground(colorInProgress)
-		case model.StatusDone:
-			labelStyle = labelStyle.Copy().Foreground(colorSuccess)
-		default:
-			labelStyle = labelStyle.Copy().Foreground(colorMuted)
+	for i, col := range m.columns {
+		if !m.columnShown(i) {
+			continue
 		}
-
+		labelStyle := lipgloss.NewStyle().Foreground(columnColor(col.Status))
 		label := labelStyle.Render(col.Name)
-		count := 0
-		if m.searchQuery == "" {
-			count = len(col.Tasks)
-		} else {
-			for _, task := range col.Tasks {
-				if m.matchesSearch(task) {
-					count++
-				}
+		visible := m.visibleTaskIndices(i)
+		count := len(visible)
+		estimate := 0.0
+		for _, idx := range visible {
+			task := col.Tasks[idx]
+			if task.Estimate != nil {
+				estimate += *task.Estimate
 			}
 		}
-		parts = append(parts, fmt.Sprintf("%s: %d", label, count))
+		if estimate > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d (%s)", label, count, m.cfg.Estimate.Unit.Format(estimate)))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s: %d", label, count))
+		}
+	}
+	// Due counts, with the keys that filter the board to them
+	overdue, today := m.dueCounts()
+	if overdue > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(colorDanger).Bold(true).Render(
+			fmt.Sprintf("⚠ %d overdue (%s)", overdue, m.keys.label("filter_overdue"))))
+	}
+	if today > 0 {
+		parts = append(parts, lipgloss.NewStyle().Foreground(colorWarning).Render(
+			fmt.Sprintf("📅 %d today (%s)", today, m.keys.label("filter_due_today"))))
 	}
 	statsText := strings.Join(parts, " | ")
+	if running := m.runningTimerTask(); running != nil {
+		timer := lipgloss.NewStyle().Foreground(colorDanger).Render(
+			fmt.Sprintf("⏱ %s", formatClock(m.currentTime.Sub(*running.TimerStart))))
+		statsText = fmt.Sprintf("%s | %s", statsText, timer)
+	}
 	if !m.currentTime.IsZero() {
 		statsText = fmt.Sprintf("%s | 🕒 %s", statsText, m.currentTime.Format("2006-01-02 15:04:05"))
 	}
 	return statsStyle.Render(statsText)
 }
 
-// renderColumn renders a single column
-func (m Model) renderColumn(index int, col model.Column) string {
+// dueCounts counts the tasks in the sprint scope matching overdueFilter
+// and dueTodayFilter, ignoring the search filter
+func (m Model) dueCounts() (overdue, today int) {
+	env := query.Env{Now: m.currentTime}
+	for i, col := range m.columns {
+		if !m.columnShown(i) {
+			continue
+		}
+		for _, task := range col.Tasks {
+			if !m.inSprintScope(task) {
+				continue
+			}
+			if overdueQuery.Match(task, env) {
+				overdue++
+			}
+			if dueTodayQuery.Match(task, env) {
+				today++
+			}
+		}
+	}
+	return overdue, today
+}
+
+// runningTimerTask returns the task whose timer is running, if any
+func (m Model) runningTimerTask() *model.Task {
+	for i := range m.columns {
+		for j := range m.columns[i].Tasks {
+			if m.columns[i].Tasks[j].TimerStart != nil {
+				return &m.columns[i].Tasks[j]
+			}
+		}
+	}
+	return nil
+}
+
+// formatClock formats a duration as HH:MM:SS for running timers
+func formatClock(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+	h := int(d / time.Hour)
+	mnt := int(d % time.Hour / time.Minute)
+	sec := int(d % time.Minute / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", h, mnt, sec)
+}
+
+// renderColumn renders a single column in a board of the given height
+func (m Model) renderColumn(index int, col model.Column, boardHeight int) string {
 	var b strings.Builder
 
 	visibleIndices := m.visibleTaskIndices(index)
@@ -205,15 +345,7 @@ func (m Model) renderColumn(index int, col model.Column) string {
 	if offset >= totalTasks {
 		offset = 0
 	}
-	titleStyle := columnTitleStyle
-	switch col.Status {
-	case model.StatusInProgress:
-		titleStyle = titleStyle.Copy().Foreground(colorInProgress)
-	case model.StatusDone:
-		titleStyle = titleStyle.Copy().Foreground(colorSuccess)
-	default:
-		titleStyle = titleStyle.Copy().Foreground(colorMuted)
-	}
+	titleStyle := columnTitleStyle.Copy().Foreground(columnColor(col.Status))
 	title := titleStyle.Render(col.Name)
 	b.WriteString(title)
 	b.WriteString("\n")
@@ -233,7 +365,7 @@ func (m Model) renderColumn(index int, col model.Column) string {
 			Render("No tasks")
 		b.WriteString(emptyMsg)
 	} else {
-		endIndex := offset + maxVisibleTasks
+		endIndex := offset + m.tasksThatFit(index, offset, boardHeight)
 		if endIndex > totalTasks {
 			endIndex = totalTasks
 		}
@@ -251,22 +383,16 @@ func (m Model) renderColumn(index int, col model.Column) string {
 	}
 
 	// Scroll down indicator
-	if offset+maxVisibleTasks < totalTasks {
+	if offset+m.tasksThatFit(index, offset, boardHeight) < totalTasks {
 		scrollDown := lipgloss.NewStyle().Foreground(colorMuted).Render("  ▼ more below")
 		b.WriteString(scrollDown)
 	}
 
 	// Apply column style with status-specific colors
 	content := b.String()
-	style := columnStyle.Copy()
-	switch col.Status {
-	case model.StatusInProgress:
-		style = style.BorderForeground(colorInProgress)
-	case model.StatusDone:
-		style = style.BorderForeground(colorSuccess)
-	default:
-		style = style.BorderForeground(colorMuted)
-	}
+	style := columnStyle.Copy().
+		Width(m.columnWidth() - 2). // lipgloss widths exclude the border
+		BorderForeground(columnColor(col.Status))
 	if index == m.currentColumn {
 		style = style.Copy().Bold(true)
 	}
@@ -292,6 +418,117 @@ func wrapText(text string, maxWidth int) string {
 	return result.String()
 }
 
+// highlightText wraps text like wrapText, rendering runes set in mask with
+// the search highlight style
+func highlightText(text string, maxWidth int, mask []bool) string {
+	if mask == nil {
+		return wrapText(text, maxWidth)
+	}
+
+	var result, segment strings.Builder
+	highlighted := false
+	flush := func() {
+		if highlighted {
+			result.WriteString(highlightStyle.Render(segment.String()))
+		} else {
+			result.WriteString(segment.String())
+		}
+		segment.Reset()
+	}
+
+	lineWidth := 0
+	for i, r := range []rune(text) {
+		charWidth := runeWidth(r)
+		if maxWidth > 0 && lineWidth+charWidth > maxWidth {
+			flush()
+			result.WriteRune('\n')
+			lineWidth = 0
+		}
+		if mask[i] != highlighted {
+			flush()
+			highlighted = mask[i]
+		}
+		segment.WriteRune(r)
+		lineWidth += charWidth
+	}
+	flush()
+	return result.String()
+}
+
+// matchMask marks the runes of text covered by a case-insensitive match of
+// any term's value; nil when nothing matches
+func matchMask(text string, terms []query.Term) []bool {
+	if len(terms) == 0 {
+		return nil
+	}
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var mask []bool
+	for _, t := range terms {
+		needle := []rune(strings.ToLower(t.Value))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if string(lower[i:i+len(needle)]) != string(needle) {
+				continue
+			}
+			if mask == nil {
+				mask = make([]bool, len(runes))
+			}
+			for j := i; j < i+len(needle); j++ {
+				mask[j] = true
+			}
+		}
+	}
+	return mask
+}
+
+// renderTag renders a tag chip, highlighting the parts matching search
+// terms. tag: terms only match whole tags.
+func renderTag(tag string, terms []query.Term) string {
+	base := tagStyle(tag)
+
+	var substrings []query.Term
+	for _, t := range terms {
+		if t.Field == "tag" {
+			if strings.EqualFold(t.Value, tag) {
+				substrings = append(substrings, query.Term{Value: tag})
+			}
+			continue
+		}
+		substrings = append(substrings, t)
+	}
+
+	mask := matchMask(tag, substrings)
+	if mask == nil {
+		return base.Copy().Padding(0, 1).Render(tag)
+	}
+
+	hl := base.Copy().Bold(true).Underline(true)
+	var b strings.Builder
+	b.WriteString(base.Render(" "))
+	runes := []rune(tag)
+	for start := 0; start < len(runes); {
+		end := start
+		for end < len(runes) && mask[end] == mask[start] {
+			end++
+		}
+		style := base
+		if mask[start] {
+			style = hl
+		}
+		b.WriteString(style.Render(string(runes[start:end])))
+		start = end
+	}
+	b.WriteString(base.Render(" "))
+	return b.String()
+}
+
 // runeWidth returns the display width of a rune (CJK chars are 2, others are 1)
 func runeWidth(r rune) int {
 	// CJK characters typically take 2 columns
@@ -308,37 +545,69 @@ func (m Model) renderTask(task model.Task, isActive bool) string {
 	var b strings.Builder
 
 	// Get max width for text wrapping (account for padding)
-	maxWidth := taskStyle.GetWidth() - 2 // subtract padding
-	if maxWidth <= 0 {
-		maxWidth = 22
+	cardWidth := m.cardWidth()
+	selected := m.selected[task.ID]
+	if selected {
+		cardWidth-- // the selection bar
 	}
+	maxWidth := cardWidth - cardPadding
+
+	// Wrap title text using character-based breaking, highlighting search matches
+	titleTerms := m.searchFilter.Highlights("title")
+	b.WriteString(highlightText(task.Title, maxWidth, matchMask(task.Title, titleTerms)))
 
-	// Wrap title text using character-based breaking
-	wrappedTitle := wrapText(task.Title, maxWidth)
-	b.WriteString(wrappedTitle)
+	// Render the first line of the description if enabled
+	if m.cfg.Display.Excerpt {
+		if excerpt := descriptionExcerpt(task.Description, maxWidth); excerpt != "" {
+			b.WriteString("\n")
+			b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Render(excerpt))
+		}
+	}
 
-	// Render due date if present (below title)
+	// Render due date if present (below title), with how far away it is
+	// for unfinished tasks
 	if task.Due != nil {
-		dueStr := task.Due.Format("2006-01-02")
-		dueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+		dueStr := due.Format(*task.Due)
+		if task.Status != model.StatusDone {
+			dueStr += " · " + due.Relative(*task.Due, m.currentTime)
+		}
+		b.WriteString("\n")
+		b.WriteString(m.dueStyle(task).Render("📅 " + dueStr))
+	}
+
+	// Render time in current column for unfinished tasks
+	if task.Status != model.StatusDone {
 		b.WriteString("\n")
-		b.WriteString(dueStyle.Render("📅 " + dueStr))
+		b.WriteString(m.renderAge(task))
+	}
+
+	// Render estimate if present
+	if task.Estimate != nil {
+		b.WriteString("\n")
+		b.WriteString("◆ " + m.cfg.Estimate.Unit.Format(*task.Estimate))
+	}
+
+	// Render running timer if present
+	if task.TimerStart != nil {
+		timerStyle := lipgloss.NewStyle().Foreground(colorDanger).Bold(true)
+		b.WriteString("\n")
+		b.WriteString(timerStyle.Render("⏱ " + formatClock(m.currentTime.Sub(*task.TimerStart))))
+	}
+
+	// Render completed pomodoros if any
+	if task.Pomodoros > 0 {
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("🍅 %d", task.Pomodoros))
 	}
 
 	// Render tags if present
 	if len(task.Tags) > 0 {
 		b.WriteString("\n")
 		lineWidth := 0
-		maxWidth := taskStyle.GetWidth()
-		if maxWidth == 0 {
-			maxWidth = 24
-		}
+		maxWidth := cardWidth
+		tagTerms := m.searchFilter.Highlights("tag")
 		for _, tag := range task.Tags {
-			tagStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFFFFF")).
-				Background(getTagColor(tag)).
-				Padding(0, 1)
-			rendered := tagStyle.Render(tag)
+			rendered := renderTag(tag, tagTerms)
 			tagWidth := lipgloss.Width(rendered)
 			space := 0
 			if lineWidth > 0 {
@@ -359,183 +628,68 @@ func (m Model) renderTask(task model.Task, isActive bool) string {
 	}
 
 	text := b.String()
+	style := taskStyle
 	if isActive {
-		return taskActiveStyle.Render(text)
-	}
-	return taskStyle.Render(text)
-}
-
-// getTagColor returns a color based on tag name hash
-func getTagColor(tag string) lipgloss.Color {
-	colors := []lipgloss.Color{
-		lipgloss.Color("#EF4444"), // red
-		lipgloss.Color("#F59E0B"), // orange
-		lipgloss.Color("#10B981"), // green
-		lipgloss.Color("#3B82F6"), // blue
-		lipgloss.Color("#8B5CF6"), // purple
-		lipgloss.Color("#EC4899"), // pink
+		style = taskActiveStyle
 	}
-	hash := 0
-	for _, c := range tag {
-		hash += int(c)
+	if selected {
+		// A bar down the left edge marks selected cards
+		style = style.Copy().
+			Border(lipgloss.ThickBorder(), false, false, false, true).
+			BorderForeground(colorSuccess)
 	}
-	return colors[hash%len(colors)]
+	return style.Copy().Width(cardWidth).Render(text)
 }
 
-// matchesSearch checks if a task matches the current search query
-func (m Model) matchesSearch(task model.Task) bool {
-	if m.searchQuery == "" {
-		return true
-	}
-
-	query := m.searchQuery
-
-	// Check for title: prefix (title-only search)
-	if strings.HasPrefix(query, "title:") {
-		titleQuery := strings.TrimPrefix(query, "title:")
-		if titleQuery == "" {
-			return true
-		}
-		return strings.Contains(strings.ToLower(task.Title), titleQuery)
-	}
-
-	// Check for desc: prefix (description-only search)
-	if strings.HasPrefix(query, "desc:") {
-		descQuery := strings.TrimPrefix(query, "desc:")
-		if descQuery == "" {
-			return true
-		}
-		return strings.Contains(strings.ToLower(task.Description), descQuery)
-	}
-
-	// Check for tag: prefix (tag-only search)
-	if strings.HasPrefix(query, "tag:") {
-		tagQuery := strings.TrimPrefix(query, "tag:")
-		if tagQuery == "" {
-			return true
-		}
-
-		for _, tag := range task.Tags {
-			if strings.EqualFold(tag, tagQuery) {
-				return true
-			}
-		}
-
-		return false
-	}
-
-	// Check for due: prefix (due date search)
-	if strings.HasPrefix(query, "due:") {
-		dueQuery := strings.TrimPrefix(query, "due:")
-		if dueQuery == "" {
-			return true
-		}
-
-		// Use local time for date comparisons to respect user's timezone
-		now := time.Now()
-		loc := now.Location()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-
-		// Special keywords
-		if dueQuery == "none" {
-			return task.Due == nil
-		}
-		if dueQuery == "today" {
-			if task.Due == nil {
-				return false
-			}
-			taskDue := time.Date(task.Due.Year(), task.Due.Month(), task.Due.Day(), 0, 0, 0, 0, loc)
-			return taskDue.Equal(today)
-		}
-		if dueQuery == "yesterday" {
-			if task.Due == nil {
-				return false
-			}
-			yesterday := today.AddDate(0, 0, -1)
-			taskDue := time.Date(task.Due.Year(), task.Due.Month(), task.Due.Day(), 0, 0, 0, 0, loc)
-			return taskDue.Equal(yesterday)
-		}
-		if dueQuery == "tomorrow" {
-			if task.Due == nil {
-				return false
-			}
-			tomorrow := today.AddDate(0, 0, 1)
-			taskDue := time.Date(task.Due.Year(), task.Due.Month(), task.Due.Day(), 0, 0, 0, 0, loc)
-			return taskDue.Equal(tomorrow)
-		}
-		if dueQuery == "overdue" {
-			if task.Due == nil {
-				return false
-			}
-			taskDue := time.Date(task.Due.Year(), task.Due.Month(), task.Due.Day(), 0, 0, 0, 0, loc)
-			return taskDue.Before(today)
-		}
-
-		// If task has no due date (for comparison operators)
-		if task.Due == nil {
-			return false
-		}
-
-		taskDue := time.Date(task.Due.Year(), task.Due.Month(), task.Due.Day(), 0, 0, 0, 0, loc)
-
-		// Check for comparison operators
-		if strings.HasPrefix(dueQuery, "<=") {
-			dateStr := strings.TrimPrefix(dueQuery, "<=")
-			if queryDate, err := time.Parse("2006-01-02", dateStr); err == nil {
-				queryDate = time.Date(queryDate.Year(), queryDate.Month(), queryDate.Day(), 0, 0, 0, 0, loc)
-				return !taskDue.After(queryDate)
-			}
-			return false
-		}
-		if strings.HasPrefix(dueQuery, ">=") {
-			dateStr := strings.TrimPrefix(dueQuery, ">=")
-			if queryDate, err := time.Parse("2006-01-02", dateStr); err == nil {
-				queryDate = time.Date(queryDate.Year(), queryDate.Month(), queryDate.Day(), 0, 0, 0, 0, loc)
-				return !taskDue.Before(queryDate)
-			}
-			return false
-		}
-		if strings.HasPrefix(dueQuery, "<") {
-			dateStr := strings.TrimPrefix(dueQuery, "<")
-			if queryDate, err := time.Parse("2006-01-02", dateStr); err == nil {
-				queryDate = time.Date(queryDate.Year(), queryDate.Month(), queryDate.Day(), 0, 0, 0, 0, loc)
-				return taskDue.Before(queryDate)
-			}
-			return false
-		}
-		if strings.HasPrefix(dueQuery, ">") {
-			dateStr := strings.TrimPrefix(dueQuery, ">")
-			if queryDate, err := time.Parse("2006-01-02", dateStr); err == nil {
-				queryDate = time.Date(queryDate.Year(), queryDate.Month(), queryDate.Day(), 0, 0, 0, 0, loc)
-				return taskDue.After(queryDate)
-			}
-			return false
-		}
-
-		// Exact match
-		taskDueStr := task.Due.Format("2006-01-02")
-		return taskDueStr == dueQuery
+// renderAge renders how long a task has been in its column, turning amber
+// and red once it passes the configured thresholds
+func (m Model) renderAge(task model.Task) string {
+	age := m.currentTime.Sub(task.StatusChangedAt)
+	days := int(age.Hours() / 24)
+
+	color := colorMuted
+	switch {
+	case days >= m.cfg.Aging.AlertDays:
+		color = colorDanger
+	case days >= m.cfg.Aging.WarnDays:
+		color = colorWarning
 	}
+	return lipgloss.NewStyle().Foreground(color).Render("⏳ " + formatAge(age))
+}
 
-	// General search: title, description, tags
-	// Search in title
-	if strings.Contains(strings.ToLower(task.Title), query) {
-		return true
+// dueStyle colors a task's due date by how close it is: red and bold when
+// overdue, amber today and the secondary color within the soon threshold.
+// Due dates of done tasks are not colored.
+func (m Model) dueStyle(task model.Task) lipgloss.Style {
+	style := lipgloss.NewStyle().Foreground(colorText)
+	if task.Due == nil || task.Status == model.StatusDone {
+		return style
 	}
-
-	// Search in description
-	if strings.Contains(strings.ToLower(task.Description), query) {
-		return true
+	switch due.Classify(*task.Due, m.currentTime, m.cfg.Due.SoonDays) {
+	case due.UrgencyOverdue:
+		return style.Foreground(colorDanger).Bold(true)
+	case due.UrgencyToday:
+		return style.Foreground(colorWarning).Bold(true)
+	case due.UrgencySoon:
+		return style.Foreground(colorSecondary)
 	}
+	return style
+}
 
-	// Search in tags
-	for _, tag := range task.Tags {
-		if strings.EqualFold(tag, query) {
-			return true
-		}
+// formatAge formats a duration compactly as minutes, hours or days
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
 	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
 
-	return false
+// matchesSearch checks if a task matches the current search filter
+func (m Model) matchesSearch(task model.Task) bool {
+	return m.searchFilter.Match(task, query.Env{Now: time.Now(), TextHits: m.searchHits})
 }
 
 // viewAddTask renders the add task view
@@ -555,7 +709,7 @@ func (m Model) viewAddTask() string {
 	b.WriteString(input)
 	b.WriteString("\n\n")
 
-	help := helpStyle.Render("Enter: Save | Esc: Cancel")
+	help := helpStyle.Render(m.keys.footer(saveFooter))
 	b.WriteString(help)
 
 	return b.String()
@@ -573,7 +727,7 @@ func (m Model) viewEditTask() string {
 	b.WriteString(input)
 	b.WriteString("\n\n")
 
-	help := helpStyle.Render("Enter: Save | Esc: Cancel")
+	help := helpStyle.Render(m.keys.footer(saveFooter))
 	b.WriteString(help)
 
 	return b.String()
@@ -591,6 +745,12 @@ func (m Model) viewEditDescription() string {
 	if task != nil {
 		info := fmt.Sprintf("Task: %s", task.Title)
 		b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(info))
+		b.WriteString("\n")
+		tracked := fmt.Sprintf("Tracked: %s", model.FormatTracked(task.TrackedAt(m.currentTime)))
+		if task.TimerStart != nil {
+			tracked += " (timer running)"
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Render(tracked))
 		b.WriteString("\n\n")
 	}
 
@@ -598,7 +758,10 @@ func (m Model) viewEditDescription() string {
 	b.WriteString(textAreaView)
 	b.WriteString("\n\n")
 
-	help := helpStyle.Render("Ctrl+S: Save | Esc: Cancel")
+	help := helpStyle.Render(m.keys.footer([]footerItem{
+		{[]string{"save_description"}, "Save"},
+		{[]string{"cancel"}, "Cancel"},
+	}))
 	b.WriteString(help)
 
 	return b.String()
@@ -612,6 +775,19 @@ func (m Model) viewEditTags() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
+	if m.hasSelection() {
+		info := fmt.Sprintf("%d selected tasks", len(m.selected))
+		b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(info))
+		b.WriteString("\n\n")
+		hint := lipgloss.NewStyle().Foreground(colorMuted).Render("Tags to add; prefix tags to remove with - (e.g., urgent, -later)")
+		b.WriteString(hint)
+		b.WriteString("\n\n")
+		b.WriteString(inputStyle.Render(m.textInput.View()))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render(m.keys.footer(saveFooter)))
+		return b.String()
+	}
+
 	task := m.getCurrentTask()
 	if task != nil {
 		info := fmt.Sprintf("Task: %s", task.Title)
@@ -627,7 +803,7 @@ func (m Model) viewEditTags() string {
 	b.WriteString(input)
 	b.WriteString("\n\n")
 
-	help := helpStyle.Render("Enter: Save | Esc: Cancel")
+	help := helpStyle.Render(m.keys.footer(saveFooter))
 	b.WriteString(help)
 
 	return b.String()
@@ -642,32 +818,135 @@ func (m Model) viewEditDue() string {
 	b.WriteString("\n\n")
 
 	task := m.getCurrentTask()
-	if task != nil {
+	if m.hasSelection() {
+		info := fmt.Sprintf("%d selected tasks", len(m.selected))
+		b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(info))
+		b.WriteString("\n\n")
+	} else if task != nil {
 		info := fmt.Sprintf("Task: %s", task.Title)
 		b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(info))
 		b.WriteString("\n\n")
 
 		if task.Due != nil {
-			currentDue := fmt.Sprintf("Current due: %s", task.Due.Format("2006-01-02"))
+			currentDue := fmt.Sprintf("Current due: %s", due.Format(*task.Due))
 			b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Render(currentDue))
 			b.WriteString("\n\n")
 		}
 	}
 
-	hint := lipgloss.NewStyle().Foreground(colorMuted).Render("Format: YYYY-MM-DD (leave empty to clear)")
-	b.WriteString(hint)
+	hint := lipgloss.NewStyle().Foreground(colorMuted).Render("e.g. " + due.Examples + " (leave empty to clear)")
+	b.WriteString(lipgloss.NewStyle().Width(inputStyle.GetWidth()).Render(hint))
 	b.WriteString("\n\n")
 
 	input := inputStyle.Render(m.dueInput.View())
 	b.WriteString(input)
+	b.WriteString("\n")
+	b.WriteString(previewDue(m, m.dueInput.Value()))
+	b.WriteString("\n\n")
+
+	help := helpStyle.Render(m.keys.footer(saveFooter))
+	b.WriteString(help)
+
+	return b.String()
+}
+
+// previewDue renders the date a due date input resolves to, updated as
+// the user types
+func previewDue(m Model, input string) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(colorMuted)
+	if strings.TrimSpace(input) == "" {
+		return mutedStyle.Render("  Clears the due date")
+	}
+	date, err := due.Parse(input, m.currentTime)
+	if err != nil {
+		return mutedStyle.Copy().Width(inputStyle.GetWidth()).PaddingLeft(2).Render(err.Error())
+	}
+	if date == nil {
+		return mutedStyle.Render("  Clears the due date")
+	}
+	return lipgloss.NewStyle().Foreground(colorSuccess).Render("  → " + due.Describe(*date, m.currentTime))
+}
+
+// viewTimerNote renders the stop timer view
+func (m Model) viewTimerNote() string {
+	var b strings.Builder
+
+	title := titleStyle.Render("⏱  Stop Timer")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	task := m.getCurrentTask()
+	if task != nil {
+		info := fmt.Sprintf("Task: %s", task.Title)
+		b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(info))
+		b.WriteString("\n\n")
+
+		if task.TimerStart != nil {
+			elapsed := fmt.Sprintf("Running for %s", formatClock(m.currentTime.Sub(*task.TimerStart)))
+			b.WriteString(lipgloss.NewStyle().Foreground(colorMuted).Render(elapsed))
+			b.WriteString("\n\n")
+		}
+	}
+
+	hint := lipgloss.NewStyle().Foreground(colorMuted).Render("Optional note for this time entry")
+	b.WriteString(hint)
+	b.WriteString("\n\n")
+
+	input := inputStyle.Render(m.textInput.View())
+	b.WriteString(input)
+	b.WriteString("\n\n")
+
+	help := helpStyle.Render(m.keys.footer([]footerItem{
+		{[]string{"submit"}, "Stop timer"},
+		{[]string{"cancel"}, "Keep running"},
+	}))
+	b.WriteString(help)
+
+	return b.String()
+}
+
+// viewEditEstimate renders the edit estimate view
+func (m Model) viewEditEstimate() string {
+	var b strings.Builder
+
+	title := titleStyle.Render("◆ Edit Estimate")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	task := m.getCurrentTask()
+	if m.hasSelection() {
+		info := fmt.Sprintf("%d selected tasks", len(m.selected))
+		b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(info))
+		b.WriteString("\n\n")
+	} else if task != nil {
+		info := fmt.Sprintf("Task: %s", task.Title)
+		b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(info))
+		b.WriteString("\n\n")
+	}
+
+	hint := lipgloss.NewStyle().Foreground(colorMuted).Render(
+		fmt.Sprintf("Estimate in %s (leave empty to clear)", m.cfg.Estimate.Unit))
+	b.WriteString(hint)
+	b.WriteString("\n\n")
+
+	input := inputStyle.Render(m.textInput.View())
+	b.WriteString(input)
 	b.WriteString("\n\n")
 
-	help := helpStyle.Render("Enter: Save | Esc: Cancel")
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n\n")
+	}
+
+	help := helpStyle.Render(m.keys.footer(saveFooter))
 	b.WriteString(help)
 
 	return b.String()
 }
 
+// maxDeleteTitles caps how many titles the bulk delete confirmation lists
+const maxDeleteTitles = 10
+
 // viewConfirmDelete renders the delete confirmation view
 func (m Model) viewConfirmDelete() string {
 	var b strings.Builder
@@ -676,17 +955,31 @@ func (m Model) viewConfirmDelete() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
-	task := m.getCurrentTask()
-	if task != nil {
-		warning := lipgloss.NewStyle().
-			Foreground(colorDanger).
-			Bold(true).
-			Render(fmt.Sprintf("Are you sure you want to delete this task?\n\n\"%s\"", task.Title))
+	warningStyle := lipgloss.NewStyle().Foreground(colorDanger).Bold(true)
+	if len(m.pendingDeleteIDs) > 1 {
+		var titles []string
+		for _, id := range m.pendingDeleteIDs {
+			if task := m.findTask(id); task != nil {
+				titles = append(titles, fmt.Sprintf("  \"%s\"", task.Title))
+			}
+		}
+		if len(titles) > maxDeleteTitles {
+			titles = append(titles[:maxDeleteTitles], fmt.Sprintf("  … and %d more", len(titles)-maxDeleteTitles))
+		}
+		b.WriteString(warningStyle.Render(fmt.Sprintf("Are you sure you want to delete these %d tasks?\n\n%s",
+			len(m.pendingDeleteIDs), strings.Join(titles, "\n"))))
+		b.WriteString("\n\n")
+	} else if len(m.pendingDeleteIDs) == 1 && m.findTask(m.pendingDeleteIDs[0]) != nil {
+		task := m.findTask(m.pendingDeleteIDs[0])
+		warning := warningStyle.Render(fmt.Sprintf("Are you sure you want to delete this task?\n\n\"%s\"", task.Title))
 		b.WriteString(warning)
 		b.WriteString("\n\n")
 	}
 
-	help := helpStyle.Render("y: Yes, delete | n/Esc: Cancel")
+	help := helpStyle.Render(m.keys.footer([]footerItem{
+		{[]string{"confirm"}, "Yes, delete"},
+		{[]string{"deny"}, "Cancel"},
+	}))
 	b.WriteString(help)
 
 	return b.String()
@@ -700,25 +993,36 @@ func (m Model) viewHelp() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
-	helpText := `Navigation:
-  ← → or h l    Move between columns
-  ↑ ↓ or j k    Move between tasks
-
-Actions:
-  a             Add new task to current column
-  e or Enter    Edit selected task title
-  i             Edit selected task description
-  t             Edit selected task tags
-  u             Edit selected task due date
-  d or Delete   Delete selected task
-  m             Move task to next column
-
-Search:
-  /             Open search input
-  Enter         Apply search filter
-  Esc           Clear search filter (when active)
-  
-  Search syntax:
+	b.WriteString("Navigation:\n")
+	b.WriteString(m.keys.helpSection("Navigation"))
+	b.WriteString("\nActions:\n")
+	b.WriteString(m.keys.helpSection("Actions"))
+	b.WriteString("\nSelection (move, delete, tags, due and archive apply to every selected task):\n")
+	b.WriteString(m.keys.helpSection("Selection"))
+	b.WriteString("\nFind & filter:\n")
+	b.WriteString(m.keys.helpSection("Find"))
+	b.WriteString("  1-9           Switch to saved view 1-9 (again to leave it)\n")
+	b.WriteString("\nEditing:\n")
+	b.WriteString(m.keys.helpSection("Editing"))
+	b.WriteString("\nFocus mode:\n")
+	b.WriteString(m.keys.helpSection("Focus"))
+	b.WriteString("\nSprint switcher and view picker:\n")
+	b.WriteString(m.keys.helpSection("Pickers"))
+	b.WriteString("\nTask details:\n")
+	b.WriteString(m.keys.helpSection("Details"))
+	b.WriteString("\nCalendar:\n")
+	b.WriteString(m.keys.helpSection("Calendar"))
+	b.WriteString("\nOther:\n")
+	b.WriteString(m.keys.helpSection("Other"))
+	b.WriteString("\n")
+
+	b.WriteString("Mouse:\n")
+	b.WriteString("  Click         Select a card or column\n")
+	b.WriteString("  Wheel         Scroll the column under the cursor\n")
+	b.WriteString("  Drag          Move a card (or the selection it is in) to the column it is dropped on\n")
+	b.WriteString("\n")
+
+	helpText := fmt.Sprintf(`Search syntax (filters as you type; %s in the input restores the previous filter):
     keyword      Search in title, description and tags
     title:text   Search only in title
     desc:text    Search only in description
@@ -731,15 +1035,27 @@ Search:
     due:today    Due today
     due:yesterday Due yesterday
     due:tomorrow Due tomorrow
-    due:overdue  Past due date
+    due:overdue  Past due date or time
     due:none     No due date set
-
-Other:
-  F5            Refresh board
-  ?             Show this help
-  q or Ctrl+C   Quit application
-  Esc           Cancel current action or quit
-`
+    status:done  In a column: todo, in_progress or done
+    est:N        Estimate equals N
+    est:>N       Estimate greater than N (also <, <=, >=)
+    est:none     No estimate set
+    "a phrase"   Match words together (title:"a phrase" for fields)
+
+  Combine terms (implicit AND):
+    tag:bug due:overdue        Both must match
+    tag:bug OR tag:ui          Either matches
+    NOT tag:bug, -tag:bug      Exclude matches
+    (tag:bug OR tag:ui) -title:spike  Group with parentheses
+
+Command palette:
+  Type an action name, e.g. "move to done", "set due tomorrow",
+  "add tag urgent", "archive done"; %s completes the highlighted command
+  so its argument can be typed inline. An empty input lists recent commands.
+
+Keys can be changed in the [keys] table of the config file.
+`, m.keys.label("cancel"), m.keys.label("complete"))
 
 	b.WriteString(helpText)
 	b.WriteString("\n")
@@ -0,0 +1,310 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/happytaoer/cli_kanban/internal/config"
+	"github.com/happytaoer/cli_kanban/internal/model"
+)
+
+// maxQuickViews is how many saved views the number keys switch between
+const maxQuickViews = 9
+
+type viewsLoadedMsg struct {
+	views []model.SavedView
+}
+
+type viewSavedMsg struct {
+	view *model.SavedView
+}
+
+type viewDeletedMsg struct{}
+
+// loadViews loads the saved views
+func (m Model) loadViews() tea.Cmd {
+	return func() tea.Msg {
+		views, err := m.db.GetViews()
+		if err != nil {
+			return errMsg{err}
+		}
+		return viewsLoadedMsg{views}
+	}
+}
+
+// applyView switches the board to a saved view; nil returns to the plain
+// board with no filter
+func (m Model) applyView(view *model.SavedView) (Model, tea.Cmd) {
+	if view == nil {
+		m.activeView = nil
+		m.sortColumns()
+		return m.clearSearch(), nil
+	}
+
+	v := *view
+	m.activeView = &v
+	m.sortColumns()
+	m.searchInput.SetValue(v.Query)
+	m, cmd, err := m.setSearchQuery(v.Query)
+	if err != nil {
+		m.err = fmt.Errorf("view %q: %w", v.Name, err)
+	}
+
+	// Keep the selection on a column the view shows
+	if !m.columnShown(m.currentColumn) {
+		for i := range m.columns {
+			if m.columnShown(i) {
+				m.currentColumn = i
+				break
+			}
+		}
+		m.currentTask = 0
+		m.ensureTaskVisible()
+	}
+	return m, cmd
+}
+
+// applyQuickView applies the nth saved view (1-based), or leaves it if it
+// is already active
+func (m Model) applyQuickView(n int) (Model, tea.Cmd) {
+	if n < 1 || n > len(m.views) {
+		return m, nil
+	}
+	view := &m.views[n-1]
+	if m.activeView != nil && m.activeView.ID == view.ID {
+		return m.applyView(nil)
+	}
+	return m.applyView(view)
+}
+
+// columnShown reports whether the active view displays a column
+func (m Model) columnShown(index int) bool {
+	if m.activeView == nil || index < 0 || index >= len(m.columns) {
+		return true
+	}
+	return m.activeView.ShowsColumn(m.columns[index].Status)
+}
+
+// sortColumns orders each column by the active view's sort
+func (m *Model) sortColumns() {
+	sort := model.SortCreated
+	if m.activeView != nil {
+		sort = m.activeView.Sort
+	}
+	for i := range m.columns {
+		model.SortTasks(m.columns[i].Tasks, sort)
+	}
+	m.refreshVisible()
+}
+
+// handleViewsKeys handles keyboard input in the saved view picker
+func (m Model) handleViewsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Row 0 is "no view", then one row per saved view
+	key := msg.String()
+	switch m.keys.screen(config.ContextViews, key) {
+	case "view_save":
+		// Save the current filter as a new view
+		m.viewMode = ViewModeSaveView
+		m.textInput.SetValue("")
+		if m.activeView != nil {
+			m.textInput.SetValue(m.activeView.Name)
+		}
+		m.textInput.Focus()
+		return m, nil
+
+	case "view_delete":
+		if m.viewCursor == 0 {
+			return m, nil
+		}
+		view := m.views[m.viewCursor-1]
+		if m.activeView != nil && m.activeView.ID == view.ID {
+			m.activeView = nil
+			m.sortColumns()
+		}
+		m.viewCursor--
+		return m, m.deleteView(view.Name)
+	}
+
+	if m.keys.input(key) == "submit" {
+		m.viewMode = ViewModeBoard
+		if m.viewCursor == 0 {
+			return m.applyView(nil)
+		}
+		return m.applyView(&m.views[m.viewCursor-1])
+	}
+
+	switch m.keys.board(key) {
+	case "up":
+		if m.viewCursor > 0 {
+			m.viewCursor--
+		}
+		return m, nil
+
+	case "down":
+		if m.viewCursor < len(m.views) {
+			m.viewCursor++
+		}
+		return m, nil
+	}
+
+	if n := quickViewNumber(key); n > 0 && n <= len(m.views) {
+		m.viewMode = ViewModeBoard
+		return m.applyView(&m.views[n-1])
+	}
+	if m.keys.closes(key, "views") {
+		m.viewMode = ViewModeBoard
+	}
+	return m, nil
+}
+
+// handleSaveViewKeys handles the view name prompt
+func (m Model) handleSaveViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.input(msg.String()) {
+	case "submit":
+		name := strings.TrimSpace(m.textInput.Value())
+		if name == "" {
+			return m, nil
+		}
+		m.viewMode = ViewModeBoard
+		m.textInput.SetValue("")
+		return m, m.saveView(m.currentViewAs(name))
+
+	case "cancel":
+		m.viewMode = ViewModeViews
+		m.textInput.SetValue("")
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// currentViewAs returns the board's filter, sort and columns as a view
+func (m Model) currentViewAs(name string) model.SavedView {
+	view := model.SavedView{Name: name, Query: m.searchQuery, Sort: model.SortCreated}
+	if m.activeView != nil {
+		view.Sort = m.activeView.Sort
+		view.Columns = m.activeView.Columns
+	}
+	return view
+}
+
+// quickViewNumber returns the view number of keys "1" to "9", or 0
+func quickViewNumber(key string) int {
+	if len(key) == 1 && key[0] >= '1' && key[0] <= '0'+maxQuickViews {
+		return int(key[0] - '0')
+	}
+	return 0
+}
+
+// saveView stores a view
+func (m Model) saveView(view model.SavedView) tea.Cmd {
+	return func() tea.Msg {
+		saved, err := m.db.SaveView(view)
+		if err != nil {
+			return errMsg{err}
+		}
+		return viewSavedMsg{saved}
+	}
+}
+
+// deleteView deletes a view by name
+func (m Model) deleteView(name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.db.DeleteView(name); err != nil {
+			return errMsg{err}
+		}
+		return viewDeletedMsg{}
+	}
+}
+
+// viewViews renders the saved view picker
+func (m Model) viewViews() string {
+	var b strings.Builder
+
+	title := titleStyle.Render("🔖 Saved Views")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	mutedStyle := lipgloss.NewStyle().Foreground(colorMuted)
+	rows := []string{"   None (all tasks)"}
+	for i, v := range m.views {
+		key := " "
+		if i < maxQuickViews {
+			key = fmt.Sprint(i + 1)
+		}
+		label := fmt.Sprintf("%s  %s", key, v.Name)
+		if v.Query != "" {
+			label += "  " + mutedStyle.Render(v.Query)
+		}
+		if v.Sort != "" && v.Sort != model.SortCreated {
+			label += mutedStyle.Render("  sort:" + string(v.Sort))
+		}
+		rows = append(rows, label)
+	}
+
+	for i, row := range rows {
+		active := (i == 0 && m.activeView == nil) || (i > 0 && m.activeView != nil && m.activeView.ID == m.views[i-1].ID)
+		line := "  " + row
+		if active {
+			line = "• " + row
+		}
+		if i == m.viewCursor {
+			line = taskActiveStyle.Copy().MarginBottom(0).Width(0).Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if len(m.views) == 0 {
+		hint := fmt.Sprintf("No saved views yet. Search with %s then press %s here to save the filter.",
+			m.keys.label("search"), m.keys.label("view_save"))
+		b.WriteString("\n")
+		b.WriteString(mutedStyle.Render(hint))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	help := helpStyle.Render(m.keys.footer([]footerItem{
+		{[]string{"up", "down"}, "Select"},
+		{[]string{"submit"}, "Apply (or 1-9)"},
+		{[]string{"view_save"}, "Save current filter"},
+		{[]string{"view_delete"}, "Delete"},
+		{[]string{"cancel"}, "Cancel"},
+	}))
+	b.WriteString(help)
+
+	return b.String()
+}
+
+// viewSaveView renders the view name prompt
+func (m Model) viewSaveView() string {
+	var b strings.Builder
+
+	title := titleStyle.Render("🔖 Save View")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	filter := m.searchQuery
+	if filter == "" {
+		filter = "(no filter)"
+	}
+	info := fmt.Sprintf("Filter: %s", filter)
+	b.WriteString(lipgloss.NewStyle().Foreground(colorSecondary).Render(info))
+	b.WriteString("\n\n")
+
+	hint := lipgloss.NewStyle().Foreground(colorMuted).Render("View name (an existing name is replaced)")
+	b.WriteString(hint)
+	b.WriteString("\n\n")
+
+	input := inputStyle.Render(m.textInput.View())
+	b.WriteString(input)
+	b.WriteString("\n\n")
+
+	help := helpStyle.Render(m.keys.footer(saveFooter))
+	b.WriteString(help)
+
+	return b.String()
+}
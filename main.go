@@ -4,15 +4,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/happytaoer/cli_kanban/internal/config"
 	"github.com/happytaoer/cli_kanban/internal/db"
+	"github.com/happytaoer/cli_kanban/internal/theme"
 	"github.com/happytaoer/cli_kanban/internal/tui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	dbPath string
+	dbPath     string
+	configPath string
+	sprintRef  string
+	themeName  string
 )
 
 func main() {
@@ -21,6 +27,9 @@ func main() {
 		Short: "A terminal-based Kanban board",
 		Long:  `cli_kanban is a beautiful TUI application for managing tasks in a Kanban board format.`,
 		RunE:  runTUI,
+		// main prints returned errors itself
+		SilenceUsage:  true,
+		SilenceErrors: true,
 	}
 
 	// Get default database path
@@ -31,6 +40,18 @@ func main() {
 	defaultDBPath := filepath.Join(homeDir, ".cli_kanban.db")
 
 	rootCmd.PersistentFlags().StringVarP(&dbPath, "db", "d", defaultDBPath, "Path to SQLite database file")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", config.DefaultPath(), "Path to TOML config file")
+
+	rootCmd.Flags().StringVar(&sprintRef, "sprint", "", "Scope the board to a sprint (ID, name, \"current\" or \"backlog\")")
+	rootCmd.Flags().StringVar(&themeName, "theme", "", "Color theme ("+strings.Join(theme.Names(), ", ")+"), overriding the config file")
+
+	rootCmd.AddCommand(newTimeCmd())
+	rootCmd.AddCommand(newSprintCmd())
+	rootCmd.AddCommand(newListCmd())
+	rootCmd.AddCommand(newEditCmd())
+	rootCmd.AddCommand(newSearchCmd())
+	rootCmd.AddCommand(newViewCmd())
+	rootCmd.AddCommand(newReportCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -38,19 +59,55 @@ func main() {
 	}
 }
 
+// openDB opens the database selected by the --db flag
+func openDB() (*db.DB, error) {
+	database, err := db.New(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+	return database, nil
+}
+
+// loadConfig loads the config file selected by the --config flag
+func loadConfig() (config.Config, error) {
+	return config.Load(configPath)
+}
+
 func runTUI(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if themeName != "" {
+		if err := theme.Validate(themeName, nil); err != nil {
+			return err
+		}
+		cfg.Theme.Name = themeName
+	}
+
 	// Initialize database
-	database, err := db.New(dbPath)
+	database, err := openDB()
 	if err != nil {
-		return fmt.Errorf("failed to initialize database: %w", err)
+		return err
 	}
 	defer database.Close()
 
 	// Create TUI model
-	model := tui.NewModel(database)
+	model := tui.NewModel(database, cfg)
+	if sprintRef != "" {
+		scope := tui.SprintScopeBacklog
+		if !isBacklogRef(sprintRef) {
+			sprint, err := database.ResolveSprint(sprintRef)
+			if err != nil {
+				return err
+			}
+			scope = sprint.ID
+		}
+		model = model.WithSprintScope(scope)
+	}
 
 	// Start TUI
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("failed to run TUI: %w", err)
 	}